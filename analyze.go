@@ -0,0 +1,64 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"time"
+)
+
+// Stats summarizes a collection of UUIDs.
+type Stats struct {
+	Total int
+
+	// Invalid counts elements that are not 16 bytes long. They are not
+	// counted anywhere else.
+	Invalid int
+
+	// Nil counts Nil UUIDs. They are also counted as version 0.
+	Nil int
+
+	// Versions and Variants count the UUIDs of each version and variant,
+	// such as VariantRFC4122.
+	Versions [16]int
+	Variants [4]int
+
+	// Duplicates counts UUIDs equal to an earlier one.
+	Duplicates int
+
+	// Earliest and Latest span the times of the Version 1, 6 and 7 UUIDs.
+	// They are zero if there are none.
+	Earliest, Latest time.Time
+}
+
+// Analyze returns statistics about ids, for example to audit an imported
+// dataset for malformed or unexpected IDs.
+func Analyze(ids Uuids) Stats {
+	s := Stats{Total: len(ids)}
+	seen := make(map[UuidKey]struct{}, len(ids))
+	for _, id := range ids {
+		if len(id) != 16 {
+			s.Invalid++
+			continue
+		}
+		if _, ok := seen[id.Key()]; ok {
+			s.Duplicates++
+		}
+		seen[id.Key()] = struct{}{}
+		if id.isNil() {
+			s.Nil++
+		}
+		s.Versions[id.Version()]++
+		s.Variants[id.Variant()]++
+		if t, ok := id.Time(); ok {
+			if s.Earliest.IsZero() || t.Before(s.Earliest) {
+				s.Earliest = t
+			}
+			if t.After(s.Latest) {
+				s.Latest = t
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,34 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyze(t *testing.T) {
+	v1 := MustParse("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v7 := MustParse("017f22e2-79b1-7cc3-98c4-dc0c0c07398f")
+	v4 := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	ms := MustParse("f47ac10b-58cc-4372-c567-0e02b2c3d479")
+	s := Analyze(Uuids{v1, v7, v4, v4, ms, Make(), nil, Uuid{1, 2}})
+	if s.Total != 8 || s.Invalid != 2 || s.Nil != 1 || s.Duplicates != 1 {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+	if s.Versions[1] != 1 || s.Versions[4] != 3 || s.Versions[7] != 1 || s.Versions[0] != 1 {
+		t.Fatalf("unexpected versions %v", s.Versions)
+	}
+	if s.Variants[VariantRFC4122] != 4 || s.Variants[VariantMicrosoft] != 1 || s.Variants[VariantNCS] != 1 {
+		t.Fatalf("unexpected variants %v", s.Variants)
+	}
+	earliest := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	if !s.Earliest.Equal(earliest) || !s.Latest.Equal(earliest.Add(time.Millisecond)) {
+		t.Fatalf("unexpected span %v - %v", s.Earliest, s.Latest)
+	}
+	if s := Analyze(nil); s.Total != 0 || !s.Earliest.IsZero() {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// BSON element types and binary subtypes, see https://bsonspec.org/spec.html.
+const (
+	bsonTypeString = 0x02
+	bsonTypeBinary = 0x05
+	bsonTypeNull   = 0x0a
+
+	bsonSubtypeUUIDOld = 0x03
+	bsonSubtypeUUID    = 0x04
+)
+
+// BSONRepresentation selects how UUIDs are laid out in BSON binary values,
+// following the uuidRepresentation option of the MongoDB drivers.
+type BSONRepresentation int
+
+const (
+	BSONStandard     BSONRepresentation = iota // subtype 4, RFC 4122 byte order
+	BSONPythonLegacy                           // subtype 3, RFC 4122 byte order
+	BSONJavaLegacy                             // subtype 3, each 8-byte half reversed
+	BSONCSharpLegacy                           // subtype 3, .NET Guid byte order
+)
+
+// BSONUuidRepresentation selects the layout written by MarshalBSONValue and
+// the byte order assumed when UnmarshalBSONValue reads binary subtype 3.
+// Subtype 4 is always read in standard order, and subtype 3 is read in
+// Python legacy order when the representation is BSONStandard.
+var BSONUuidRepresentation = BSONStandard
+
+// bsonLegacyOrder converts between the RFC 4122 byte order and the subtype
+// 3 layout of rep. Each conversion is its own inverse.
+func bsonLegacyOrder(dst, src []byte, rep BSONRepresentation) {
+	switch rep {
+	case BSONJavaLegacy:
+		for i := 0; i < 8; i++ {
+			dst[i], dst[8+i] = src[7-i], src[15-i]
+		}
+	case BSONCSharpLegacy:
+		swapFields(dst, src)
+	default:
+		copy(dst, src[:16])
+	}
+}
+
+var errBSONInvalid = errors.New("uuid: invalid BSON value")
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface of the
+// MongoDB Go driver (v2). The UUID is stored as binary subtype 4, or subtype
+// 3 for the legacy values of BSONUuidRepresentation. An empty Uuid is stored
+// as BSON null.
+func (uuid Uuid) MarshalBSONValue() (byte, []byte, error) {
+	if len(uuid) == 0 {
+		return bsonTypeNull, nil, nil
+	}
+	if len(uuid) != 16 {
+		return 0, nil, errInvalidLength
+	}
+	data := make([]byte, 0, 21)
+	data = binary.LittleEndian.AppendUint32(data, 16)
+	if BSONUuidRepresentation == BSONStandard {
+		data = append(data, bsonSubtypeUUID)
+		return bsonTypeBinary, append(data, uuid...), nil
+	}
+	data = append(data, bsonSubtypeUUIDOld)
+	data = data[:21]
+	bsonLegacyOrder(data[5:], uuid, BSONUuidRepresentation)
+	return bsonTypeBinary, data, nil
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface of the
+// MongoDB Go driver (v2). It accepts binary subtypes 4 and 3, strings
+// understood by Parse, and null.
+func (uuid *Uuid) UnmarshalBSONValue(typ byte, data []byte) error {
+	switch typ {
+	case bsonTypeNull:
+		*uuid = nil
+		return nil
+	case bsonTypeBinary:
+		if len(data) != 21 || binary.LittleEndian.Uint32(data) != 16 {
+			return errBSONInvalid
+		}
+		id := Make()
+		switch data[4] {
+		case bsonSubtypeUUID:
+			copy(id, data[5:])
+		case bsonSubtypeUUIDOld:
+			bsonLegacyOrder(id, data[5:], BSONUuidRepresentation)
+		default:
+			return fmt.Errorf("uuid: unsupported BSON binary subtype %#x", data[4])
+		}
+		*uuid = id
+		return nil
+	case bsonTypeString:
+		if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data)-4 || data[len(data)-1] != 0 {
+			return errBSONInvalid
+		}
+		id, err := Parse(string(data[4 : len(data)-1]))
+		if err != nil {
+			return err
+		}
+		*uuid = id
+		return nil
+	}
+	return fmt.Errorf("uuid: cannot unmarshal BSON type %#x into Uuid", typ)
+}
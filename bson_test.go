@@ -0,0 +1,100 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBSON(t *testing.T) {
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	typ, data, err := id.MarshalBSONValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := append([]byte{16, 0, 0, 0, 4}, id...)
+	if typ != 0x05 || !bytes.Equal(data, expected) {
+		t.Fatalf("want %#x %x got %#x %x", 0x05, expected, typ, data)
+	}
+	var id2 Uuid
+	if err := id2.UnmarshalBSONValue(typ, data); err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+
+	str := append([]byte{37, 0, 0, 0}, id.String()...)
+	str = append(str, 0)
+	id2 = nil
+	if err := id2.UnmarshalBSONValue(0x02, str); err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+
+	typ, data, err = Uuid(nil).MarshalBSONValue()
+	if err != nil || typ != 0x0a || data != nil {
+		t.Fatalf("empty Uuid should marshal to null, got %#x %x %v", typ, data, err)
+	}
+	if err := id2.UnmarshalBSONValue(typ, data); err != nil || id2 != nil {
+		t.Fatalf("null should unmarshal to an empty Uuid, got %v %v", id2, err)
+	}
+
+	bad := []struct {
+		typ  byte
+		data []byte
+	}{
+		{0x05, append([]byte{16, 0, 0, 0, 0}, id...)},
+		{0x05, append([]byte{16, 0, 0, 0, 5}, id...)},
+		{0x05, append([]byte{15, 0, 0, 0, 4}, id[1:]...)},
+		{0x02, []byte{2, 0, 0, 0, 'x'}},
+		{0x10, []byte{1, 0, 0, 0}},
+	}
+	for _, b := range bad {
+		if err := id2.UnmarshalBSONValue(b.typ, b.data); err == nil {
+			t.Fatalf("unmarshaling %#x %x should have failed", b.typ, b.data)
+		}
+	}
+}
+
+func TestBSONLegacy(t *testing.T) {
+	defer func(rep BSONRepresentation) { BSONUuidRepresentation = rep }(BSONUuidRepresentation)
+	id := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	tests := []struct {
+		rep      BSONRepresentation
+		expected string
+	}{
+		{BSONPythonLegacy, "00112233445566778899aabbccddeeff"},
+		{BSONJavaLegacy, "7766554433221100ffeeddccbbaa9988"},
+		{BSONCSharpLegacy, "33221100554477668899aabbccddeeff"},
+	}
+	for _, test := range tests {
+		BSONUuidRepresentation = test.rep
+		typ, data, err := id.MarshalBSONValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if typ != 0x05 || len(data) != 21 || data[4] != 3 || Uuid(data[5:]).HexString() != test.expected {
+			t.Fatalf("representation %d: want subtype 3 %s got %#x %x", test.rep, test.expected, typ, data)
+		}
+		var id2 Uuid
+		if err := id2.UnmarshalBSONValue(typ, data); err != nil {
+			t.Fatal(err)
+		}
+		if !id.Equal(id2) {
+			t.Fatalf("representation %d: want %v got %v", test.rep, id, id2)
+		}
+		// Subtype 4 is read in standard order regardless.
+		BSONUuidRepresentation = BSONStandard
+		typ, data, _ = id.MarshalBSONValue()
+		BSONUuidRepresentation = test.rep
+		if err := id2.UnmarshalBSONValue(typ, data); err != nil || !id.Equal(id2) {
+			t.Fatalf("representation %d: want %v got %v, %v", test.rep, id, id2, err)
+		}
+	}
+}
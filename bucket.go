@@ -0,0 +1,31 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// Bucket maps uuid to one of n buckets, numbered from 0, with the jump
+// consistent hash of Lamping and Veach. When n grows to n+1, only about
+// 1/(n+1) of the UUIDs move, all to the new bucket. The mapping is fixed
+// and will not change between releases, so all services shard alike. It
+// panics if n is not positive.
+func (uuid Uuid) Bucket(n int) int {
+	if n <= 0 {
+		panic("uuid: Bucket: n must be positive")
+	}
+	hi, lo := uuid.Uint64Pair()
+	// Time-based UUIDs vary mostly in one half or the other, so mix both
+	// with the SplitMix64 finalizer.
+	key := hi ^ lo
+	key = (key ^ key>>30) * 0xbf58476d1ce4e5b9
+	key = (key ^ key>>27) * 0x94d049bb133111eb
+	key ^= key >> 31
+
+	var b, j int64 = -1, 0
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(1<<31) / float64(key>>33+1)))
+	}
+	return int(b)
+}
@@ -0,0 +1,42 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+)
+
+func TestBucket(t *testing.T) {
+	// The mapping must never change.
+	id := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	for n, want := range map[int]int{1: 0, 2: 0, 10: 0, 100: 92, 1000: 357} {
+		if b := id.Bucket(n); b != want {
+			t.Fatalf("Bucket(%d): want %d got %d", n, want, b)
+		}
+	}
+
+	const count, n = 10000, 10
+	var sizes [n + 1]int
+	moved := 0
+	for i := 0; i < count; i++ {
+		id := MakeV7()
+		b := id.Bucket(n)
+		sizes[b]++
+		if b2 := id.Bucket(n + 1); b2 != b {
+			if b2 != n {
+				t.Fatalf("%v moved from %d to %d", id, b, b2)
+			}
+			moved++
+		}
+	}
+	for b, size := range sizes[:n] {
+		if size < count/n*8/10 || size > count/n*12/10 {
+			t.Fatalf("bucket %d has %d of %d", b, size, count)
+		}
+	}
+	if moved < count/(n+1)*8/10 || moved > count/(n+1)*12/10 {
+		t.Fatalf("%d of %d moved", moved, count)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import "encoding/binary"
+
+// swapFields reverses the byte order of the first three fields of a UUID
+// (time_low, time_mid and time_hi_and_version), converting between the RFC
+// 4122 big-endian layout and the mixed-endian layout of Microsoft GUIDs.
+func swapFields(dst, src []byte) {
+	dst[0], dst[1], dst[2], dst[3] = src[3], src[2], src[1], src[0]
+	dst[4], dst[5] = src[5], src[4]
+	dst[6], dst[7] = src[7], src[6]
+	copy(dst[8:], src[8:16])
+}
+
+// ToMSSQL returns uuid in the byte order SQL Server uses for
+// uniqueidentifier values and .NET Guid.ToByteArray returns.
+func (uuid Uuid) ToMSSQL() []byte {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	b := make([]byte, 16)
+	swapFields(b, uuid)
+	return b
+}
+
+// FromMSSQL converts a 16-byte SQL Server uniqueidentifier or .NET
+// Guid.ToByteArray value to a Uuid.
+func FromMSSQL(b []byte) (Uuid, error) {
+	if len(b) != 16 {
+		return nil, errInvalidLength
+	}
+	uuid := Make()
+	swapFields(uuid, b)
+	return uuid, nil
+}
+
+// ToBinOrdered returns uuid in the layout produced by MySQL's
+// UUID_TO_BIN(uuid, 1): time_hi_and_version and time_mid are moved in front
+// of time_low so that Version 1 UUIDs sort by time.
+func (uuid Uuid) ToBinOrdered() []byte {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	b := make([]byte, 16)
+	copy(b[0:2], uuid[6:8])
+	copy(b[2:4], uuid[4:6])
+	copy(b[4:8], uuid[0:4])
+	copy(b[8:], uuid[8:])
+	return b
+}
+
+// FromBinOrdered converts a 16-byte value in the layout of MySQL's
+// UUID_TO_BIN(uuid, 1) back to a Uuid, like BIN_TO_UUID(b, 1).
+func FromBinOrdered(b []byte) (Uuid, error) {
+	if len(b) != 16 {
+		return nil, errInvalidLength
+	}
+	uuid := Make()
+	copy(uuid[6:8], b[0:2])
+	copy(uuid[4:6], b[2:4])
+	copy(uuid[0:4], b[4:8])
+	copy(uuid[8:], b[8:])
+	return uuid, nil
+}
+
+// GUID has the layout of the Win32 GUID structure. Its fields hold the
+// first three fields of a UUID as integers, so in memory on little-endian
+// Windows they have the byte order of ToMSSQL. It converts directly to
+// syscall.GUID and the GUID type of golang.org/x/sys/windows:
+//
+//	g := windows.GUID(id.GUID())
+//	id := uuid.FromGUID(uuid.GUID(g))
+type GUID struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// GUID returns uuid as a Win32 GUID structure.
+func (uuid Uuid) GUID() GUID {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	g := GUID{
+		Data1: binary.BigEndian.Uint32(uuid[0:]),
+		Data2: binary.BigEndian.Uint16(uuid[4:]),
+		Data3: binary.BigEndian.Uint16(uuid[6:]),
+	}
+	copy(g.Data4[:], uuid[8:])
+	return g
+}
+
+// FromGUID converts a Win32 GUID structure to a Uuid.
+func FromGUID(g GUID) Uuid {
+	uuid := Make()
+	binary.BigEndian.PutUint32(uuid[0:], g.Data1)
+	binary.BigEndian.PutUint16(uuid[4:], g.Data2)
+	binary.BigEndian.PutUint16(uuid[6:], g.Data3)
+	copy(uuid[8:], g.Data4[:])
+	return uuid
+}
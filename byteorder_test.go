@@ -0,0 +1,69 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestMSSQL(t *testing.T) {
+	id := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	// new Guid("00112233-4455-6677-8899-aabbccddeeff").ToByteArray()
+	expected := []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+	b := id.ToMSSQL()
+	if !bytes.Equal(b, expected) {
+		t.Fatalf("want %x got %x", expected, b)
+	}
+	id2, err := FromMSSQL(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+	if _, err := FromMSSQL(b[1:]); err != errInvalidLength {
+		t.Fatalf("want %v got %v", errInvalidLength, err)
+	}
+}
+
+func TestBinOrdered(t *testing.T) {
+	id := MustParse("6ccd780c-baba-1026-9564-5b8c656024db")
+	// SELECT HEX(UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1))
+	expected := "1026baba6ccd780c95645b8c656024db"
+	b := id.ToBinOrdered()
+	if Uuid(b).HexString() != expected {
+		t.Fatalf("want %s got %x", expected, b)
+	}
+	id2, err := FromBinOrdered(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+	if _, err := FromBinOrdered(b[1:]); err != errInvalidLength {
+		t.Fatalf("want %v got %v", errInvalidLength, err)
+	}
+}
+
+func TestGUID(t *testing.T) {
+	id := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	expected := GUID{0x00112233, 0x4455, 0x6677, [8]byte{0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}}
+	g := id.GUID()
+	if g != expected {
+		t.Fatalf("want %+v got %+v", expected, g)
+	}
+	// The structure in little-endian memory has the SQL Server byte order.
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, g)
+	if !bytes.Equal(buf.Bytes(), id.ToMSSQL()) {
+		t.Fatalf("want %x got %x", id.ToMSSQL(), buf.Bytes())
+	}
+	if id2 := FromGUID(g); !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+}
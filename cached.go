@@ -0,0 +1,64 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"log/slog"
+)
+
+// Cached is a UUID with its canonical string computed once, for long-lived
+// IDs that are formatted many times, such as in logs or metric labels.
+// Cached values are comparable and immutable, and equal Cached values hold
+// equal UUIDs. The zero value is the Nil UUID, and equals NewCached(Make()).
+type Cached struct {
+	key UuidKey
+	s   string
+}
+
+// NewCached returns uuid with its canonical string cached. It panics if
+// uuid is not 16 bytes long.
+func NewCached(uuid Uuid) Cached {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	// The Nil UUID keeps the zero value's empty string, so that the two
+	// compare equal.
+	if uuid.isNil() {
+		return Cached{}
+	}
+	return Cached{key: uuid.Key(), s: uuid.String()}
+}
+
+// String returns the cached canonical form without allocating.
+func (c Cached) String() string {
+	if c.s == "" {
+		return nilString
+	}
+	return c.s
+}
+
+// Key returns the UUID as a UuidKey.
+func (c Cached) Key() UuidKey {
+	return c.key
+}
+
+// Uuid returns a copy of the UUID.
+func (c Cached) Uuid() Uuid {
+	return append(Make()[:0], c.key[:]...)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Cached) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// LogValue implements slog.LogValuer. It honours RedactLogs like
+// Uuid.LogValue.
+func (c Cached) LogValue() slog.Value {
+	if RedactLogs {
+		return c.key.LogValue()
+	}
+	return slog.StringValue(c.String())
+}
@@ -0,0 +1,49 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCached(t *testing.T) {
+	id := MakeV4()
+	c := NewCached(id)
+	if c.String() != id.String() || c.Key() != id.Key() || !c.Uuid().Equal(id) {
+		t.Fatalf("want %v got %v", id, c)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _ = c.String() }); allocs != 0 {
+		t.Fatalf("want 0 allocs got %v", allocs)
+	}
+	c.Uuid()[0]++
+	if !c.Uuid().Equal(id) {
+		t.Fatal("Uuid should return a copy")
+	}
+	if c != NewCached(id) {
+		t.Fatal("Cached values should be comparable")
+	}
+	b, err := json.Marshal(c)
+	if err != nil || string(b) != `"`+id.String()+`"` {
+		t.Fatalf("unexpected JSON %s %v", b, err)
+	}
+	var zero Cached
+	if s := zero.String(); s != "00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("unexpected zero value %s", s)
+	}
+	if zero != NewCached(Make()) {
+		t.Fatal("the zero value should equal the cached Nil UUID")
+	}
+	for _, id := range []Uuid{nil, make(Uuid, 15)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("NewCached(%x) should panic", []byte(id))
+				}
+			}()
+			NewCached(id)
+		}()
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"time"
+)
+
+// MinTimeuuid returns the smallest Cassandra timeuuid for the millisecond
+// containing t, like the CQL minTimeuuid function. It is meant to be used
+// as a query bound and never stored.
+func MinTimeuuid(t time.Time) Uuid {
+	uuid := Make()
+	putV1(uuid, uint64(t.UnixMilli())*1e4+gregorianOffset, 0, nil)
+	for i := 8; i < 16; i++ {
+		uuid[i] = 0x80
+	}
+	return uuid
+}
+
+// MaxTimeuuid returns the largest Cassandra timeuuid for the millisecond
+// containing t, like the CQL maxTimeuuid function. It is meant to be used
+// as a query bound and never stored.
+func MaxTimeuuid(t time.Time) Uuid {
+	uuid := Make()
+	putV1(uuid, uint64(t.UnixMilli()+1)*1e4+gregorianOffset-1, 0, nil)
+	for i := 8; i < 16; i++ {
+		uuid[i] = 0x7f
+	}
+	return uuid
+}
+
+// CompareTimeuuid compares two Version 1 UUIDs the way Cassandra orders
+// timeuuid columns: by timestamp first, then by the remaining eight bytes
+// compared as signed bytes.
+func CompareTimeuuid(a, b Uuid) int {
+	if ta, tb := a.v1Time(), b.v1Time(); ta != tb {
+		if ta < tb {
+			return -1
+		}
+		return 1
+	}
+	for i := 8; i < 16; i++ {
+		if ca, cb := int8(a[i]), int8(b[i]); ca != cb {
+			if ca < cb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMinMaxTimeuuid(t *testing.T) {
+	at := time.Date(2013, 3, 29, 15, 56, 27, 750032000, time.UTC)
+	min, max := MinTimeuuid(at), MaxTimeuuid(at)
+	if s := min.String(); s != "3722b860-9889-11e2-8080-808080808080" {
+		t.Fatalf("unexpected minTimeuuid %s", s)
+	}
+	if s := max.String(); s != "3722df6f-9889-11e2-7f7f-7f7f7f7f7f7f" {
+		t.Fatalf("unexpected maxTimeuuid %s", s)
+	}
+	id := MustParse("3722b9a0-9889-11e2-871e-844bf591482a")
+	if CompareTimeuuid(min, id) != -1 || CompareTimeuuid(id, max) != -1 {
+		t.Fatalf("%v should sort between %v and %v", id, min, max)
+	}
+	for i := 0; i < 100; i++ {
+		id := MakeV1()
+		ts, _ := id.Time()
+		if CompareTimeuuid(MinTimeuuid(ts), id) != -1 {
+			t.Fatalf("%v should sort after minTimeuuid", id)
+		}
+		if CompareTimeuuid(id, MaxTimeuuid(ts)) != -1 {
+			t.Fatalf("%v should sort before maxTimeuuid", id)
+		}
+	}
+}
+
+func TestCompareTimeuuid(t *testing.T) {
+	a := MustParse("3722b9a0-9889-11e2-071e-844bf591482a")
+	b := MustParse("3722b9a0-9889-11e2-871e-844bf591482a")
+	if CompareTimeuuid(b, a) != -1 || CompareTimeuuid(a, b) != 1 || CompareTimeuuid(a, a) != 0 {
+		t.Fatal("remaining bytes should compare as signed bytes")
+	}
+	c := MustParse("3722b9a1-9889-11e2-071e-844bf591482a")
+	if CompareTimeuuid(a, c) != -1 {
+		t.Fatal("timestamps should compare first")
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+)
+
+// CBOR encodings, see RFC 8949. Tag 37 marks a binary UUID.
+const (
+	cborTagUUID0   = 0xd8 // tag, 1-byte argument follows
+	cborTagUUID1   = 37
+	cborBytes16    = 0x40 | 16
+	cborSimpleNull = 0xf6
+)
+
+var errCBORInvalid = errors.New("uuid: invalid CBOR value")
+
+// MarshalCBOR implements the cbor.Marshaler interface of
+// github.com/fxamacker/cbor. The UUID is encoded as a 16-byte byte string
+// under tag 37, an empty Uuid as null.
+func (uuid Uuid) MarshalCBOR() ([]byte, error) {
+	if len(uuid) == 0 {
+		return []byte{cborSimpleNull}, nil
+	}
+	if len(uuid) != 16 {
+		return nil, errInvalidLength
+	}
+	data := append(make([]byte, 0, 19), cborTagUUID0, cborTagUUID1, cborBytes16)
+	return append(data, uuid...), nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface of
+// github.com/fxamacker/cbor. It accepts a 16-byte byte string, with or
+// without tag 37, and null.
+func (uuid *Uuid) UnmarshalCBOR(data []byte) error {
+	if len(data) == 1 && data[0] == cborSimpleNull {
+		*uuid = nil
+		return nil
+	}
+	if len(data) == 19 && data[0] == cborTagUUID0 && data[1] == cborTagUUID1 {
+		data = data[2:]
+	}
+	if len(data) != 17 || data[0] != cborBytes16 {
+		return errCBORInvalid
+	}
+	id := Make()
+	copy(id, data[1:])
+	*uuid = id
+	return nil
+}
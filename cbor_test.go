@@ -0,0 +1,51 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCBOR(t *testing.T) {
+	id := MakeV4()
+	data, err := id.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := append([]byte{0xd8, 0x25, 0x50}, id...); !bytes.Equal(data, expected) {
+		t.Fatalf("want %x got %x", expected, data)
+	}
+	for _, data := range [][]byte{data, data[2:]} {
+		var id2 Uuid
+		if err := id2.UnmarshalCBOR(data); err != nil {
+			t.Fatal(err)
+		}
+		if !id.Equal(id2) {
+			t.Fatalf("want %v got %v", id, id2)
+		}
+	}
+
+	data, err = Uuid(nil).MarshalCBOR()
+	if err != nil || !bytes.Equal(data, []byte{0xf6}) {
+		t.Fatalf("empty Uuid should marshal to null, got %x %v", data, err)
+	}
+	id2 := MakeV4()
+	if err := id2.UnmarshalCBOR(data); err != nil || id2 != nil {
+		t.Fatalf("null should unmarshal to an empty Uuid, got %v %v", id2, err)
+	}
+
+	bad := [][]byte{
+		nil,
+		append([]byte{0xd8, 0x24, 0x50}, id...),
+		append([]byte{0x4f}, id[1:]...),
+		append([]byte{0xd8, 0x25, 0x50}, id[1:]...),
+	}
+	for _, b := range bad {
+		if err := id2.UnmarshalCBOR(b); err != errCBORInvalid {
+			t.Fatalf("unmarshaling %x should have failed", b)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/rand"
+	"io"
+	mathrand "math/rand/v2"
+	"sync"
+	"time"
+)
+
+// chacha8Reader is a ChaCha8 keystream that is safe for concurrent use. It
+// is reseeded under the same rules as the package AES-CTR streams.
+type chacha8Reader struct {
+	mu         sync.Mutex
+	c          *mathrand.ChaCha8
+	bytes      int64
+	keyed      time.Time
+	generation uint64
+}
+
+func (r *chacha8Reader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stale() {
+		if err := r.reseed(); err != nil {
+			return 0, err
+		}
+	}
+	r.bytes += int64(len(p))
+	return r.c.Read(p)
+}
+
+// reseed replaces the seed of r with a fresh value from crypto/rand.
+func (r *chacha8Reader) reseed() error {
+	var seed [32]byte
+	if _, err := io.ReadFull(rand.Reader, seed[:]); err != nil {
+		return err
+	}
+	if r.c == nil {
+		r.c = mathrand.NewChaCha8(seed)
+	} else {
+		r.c.Seed(seed)
+	}
+	r.bytes = 0
+	r.keyed = time.Now()
+	r.generation = streamGeneration.Load()
+	streamRekeys.Add(1)
+	return nil
+}
+
+// stale reports whether r must be reseeded before further use, like
+// ctrStream.stale.
+func (r *chacha8Reader) stale() bool {
+	return r.c == nil || r.generation != streamGeneration.Load() ||
+		RekeyBytes > 0 && r.bytes >= RekeyBytes ||
+		RekeyInterval > 0 && time.Since(r.keyed) >= RekeyInterval
+}
+
+// NewChaCha8Reader returns a random source backed by a ChaCha8 keystream
+// seeded from crypto/rand, for use as StdGenerator.Rand. ChaCha8 is the
+// 8-round variant of ChaCha20 from math/rand/v2; the package depends only
+// on the standard library, so the 20-round cipher from
+// golang.org/x/crypto is not available. ChaCha8 runs in software, so on
+// platforms without AES instructions it is considerably faster than the
+// default AES-CTR stream.
+//
+// The reader is safe for concurrent use. It is reseeded from crypto/rand
+// by Rekey and ReseedAfterFork, and after RekeyBytes or RekeyInterval, like
+// the package AES-CTR streams.
+func NewChaCha8Reader() (io.Reader, error) {
+	r := new(chacha8Reader)
+	if err := r.reseed(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
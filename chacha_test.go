@@ -0,0 +1,68 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChaCha8Reader(t *testing.T) {
+	r, err := NewChaCha8Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &StdGenerator{Rand: r}
+	seen := make(map[UuidKey]bool)
+	for i := 0; i < 1000; i++ {
+		id, err := g.NewV4()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.Version() != 4 || seen[id.Key()] {
+			t.Fatalf("unexpected UUID %v", id)
+		}
+		seen[id.Key()] = true
+	}
+	r2, _ := NewChaCha8Reader()
+	a, b := make([]byte, 16), make([]byte, 16)
+	r.Read(a)
+	r2.Read(b)
+	if string(a) == string(b) {
+		t.Fatal("readers should be seeded independently")
+	}
+}
+
+func TestChaCha8Rekey(t *testing.T) {
+	defer func(n int64, d time.Duration) { RekeyBytes, RekeyInterval = n, d }(RekeyBytes, RekeyInterval)
+	r, _ := NewChaCha8Reader()
+	b := make([]byte, 16)
+	rekeyed := func(what string, f func()) {
+		r.Read(b)
+		n := streamRekeys.Load()
+		f()
+		r.Read(b)
+		if streamRekeys.Load() == n {
+			t.Fatalf("reader not reseeded after %s", what)
+		}
+	}
+	rekeyed("Rekey", func() { Rekey() })
+	rekeyed("ReseedAfterFork", func() { ReseedAfterFork() })
+	rekeyed("RekeyBytes", func() { RekeyBytes = 16 })
+	RekeyBytes = 0
+	rekeyed("RekeyInterval", func() {
+		RekeyInterval = time.Nanosecond
+		time.Sleep(time.Millisecond)
+	})
+}
+
+func BenchmarkChaCha8V4(b *testing.B) {
+	r, _ := NewChaCha8Reader()
+	g := &StdGenerator{Rand: r}
+	b.SetBytes(16)
+	for n := b.N; n > 0; n-- {
+		g.NewV4()
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+)
+
+var (
+	errCheckCode  = errors.New("uuid: invalid check code")
+	errCheckDigit = errors.New("uuid: check code has a typo")
+)
+
+// luhn32 returns the Luhn mod 32 sum of the Crockford base32 values in
+// digits, doubling every second value starting with the last if double is
+// set.
+func luhn32(digits []byte, double bool) int {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		v := int(digits[i])
+		if double {
+			v *= 2
+			v = v/32 + v%32
+		}
+		sum += v
+		double = !double
+	}
+	return sum % 32
+}
+
+// CheckCode returns uuid as 26 characters of Crockford base32 followed by
+// a Luhn mod 32 check character, 27 in all. It is meant for codes that
+// people type, such as order references: ParseCheckCode detects every
+// single mistyped character and most swaps of adjacent characters before
+// the code reaches a database.
+func (uuid Uuid) CheckCode() string {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	b := uuid.appendBase32(make([]byte, 0, 27), crockford)
+	var values [26]byte
+	for i, c := range b {
+		values[i] = crockfordDec[c]
+	}
+	return string(append(b, crockford[(32-luhn32(values[:], true))%32]))
+}
+
+// ParseCheckCode decodes a code written by CheckCode. As Crockford base32
+// intends for human input, it ignores case and hyphens and reads I and L as
+// 1 and O as 0. A code with a wrong check character fails with a distinct
+// error from one that is malformed.
+func ParseCheckCode(s string) (Uuid, error) {
+	var values [27]byte
+	var norm [26]byte
+	n := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '-':
+			continue
+		case 'I', 'i', 'L', 'l':
+			c = '1'
+		case 'O', 'o':
+			c = '0'
+		}
+		v := crockfordDec[c]
+		if v == 0xff || n == len(values) {
+			return nil, errCheckCode
+		}
+		if n < len(norm) {
+			norm[n] = crockford[v]
+		}
+		values[n] = v
+		n++
+	}
+	if n != len(values) {
+		return nil, errCheckCode
+	}
+	if luhn32(values[:], false) != 0 {
+		return nil, errCheckDigit
+	}
+	uuid, ok := decodeBase32(string(norm[:]))
+	if !ok {
+		return nil, errCheckCode
+	}
+	return uuid, nil
+}
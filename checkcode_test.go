@@ -0,0 +1,52 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckCode(t *testing.T) {
+	id := MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	code := id.CheckCode()
+	if len(code) != 27 || code[:26] != id.ToULID() {
+		t.Fatalf("unexpected code %s", code)
+	}
+	for _, s := range []string{
+		code,
+		strings.ToLower(code),
+		code[:5] + "-" + code[5:10] + "-" + code[10:],
+		strings.ReplaceAll(strings.ReplaceAll(code, "1", "l"), "0", "O"),
+	} {
+		if got, err := ParseCheckCode(s); err != nil || !got.Equal(id) {
+			t.Fatalf("%s: want %v got %v %v", s, id, got, err)
+		}
+	}
+
+	// Every single-character substitution is detected.
+	for i := 0; i < len(code); i++ {
+		for j := 0; j < len(crockford); j++ {
+			if crockford[j] == code[i] {
+				continue
+			}
+			typo := code[:i] + crockford[j:j+1] + code[i+1:]
+			if _, err := ParseCheckCode(typo); err == nil {
+				t.Fatalf("typo %s not detected", typo)
+			}
+		}
+	}
+	for _, s := range []string{code[:26], code + "0", code[:26] + "U", "*" + code[1:]} {
+		if _, err := ParseCheckCode(s); err != errCheckCode {
+			t.Fatalf("%s: want %v got %v", s, errCheckCode, err)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		id := MakeV4()
+		if got, err := ParseCheckCode(id.CheckCode()); err != nil || !got.Equal(id) {
+			t.Fatalf("want %v got %v %v", id, got, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+)
+
+const (
+	feistelRounds = 8
+	mask61        = 1<<61 - 1
+)
+
+// Cipher is a keyed, reversible permutation of UUIDs. It encrypts the 122
+// bits of a UUID that are not version or variant bits and leaves those six
+// bits unchanged, so the result is a valid UUID of the same version. This
+// allows time-ordered internal IDs to be exposed without revealing their
+// creation order.
+//
+// Cipher is a format-preserving Feistel network with AES as its round
+// function. It is deterministic: equal UUIDs encrypt to equal UUIDs.
+type Cipher struct {
+	block cipher.Block
+}
+
+// NewCipher returns a Cipher using the given AES key, which must be 16, 24
+// or 32 bytes long.
+func NewCipher(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{block: block}, nil
+}
+
+// round computes the Feistel round function of round i applied to x.
+func (c *Cipher) round(i int, x uint64) uint64 {
+	var b [aes.BlockSize]byte
+	b[0] = byte(i)
+	binary.BigEndian.PutUint64(b[8:], x)
+	c.block.Encrypt(b[:], b[:])
+	return binary.BigEndian.Uint64(b[:]) & mask61
+}
+
+// split extracts the 122 payload bits of uuid as two 61-bit halves.
+func split(uuid Uuid) (a, b uint64) {
+	hi, lo := uuid.Uint64Pair()
+	hi = hi>>16<<12 | hi&0xfff // 60 bits without the version
+	lo &= 1<<62 - 1            // 62 bits without the variant
+	return hi<<1 | lo>>61, lo & mask61
+}
+
+// join stores two 61-bit halves into the payload bits of a copy of uuid.
+func join(uuid Uuid, a, b uint64) Uuid {
+	hi, lo := uuid.Uint64Pair()
+	phi, plo := a>>1, (a&1)<<61|b
+	hi = phi>>12<<16 | hi&0xf000 | phi&0xfff
+	lo = lo&^(1<<62-1) | plo
+	return FromUint64Pair(hi, lo)
+}
+
+// Encrypt returns the encryption of uuid.
+func (c *Cipher) Encrypt(uuid Uuid) Uuid {
+	a, b := split(uuid)
+	for i := 0; i < feistelRounds; i++ {
+		a, b = b, a^c.round(i, b)
+	}
+	return join(uuid, a, b)
+}
+
+// Decrypt returns the UUID whose encryption is uuid.
+func (c *Cipher) Decrypt(uuid Uuid) Uuid {
+	a, b := split(uuid)
+	for i := feistelRounds - 1; i >= 0; i-- {
+		a, b = b^c.round(i, a), a
+	}
+	return join(uuid, a, b)
+}
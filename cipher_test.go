@@ -0,0 +1,58 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+)
+
+func TestCipher(t *testing.T) {
+	c, err := NewCipher([]byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, gen := range []func() Uuid{MakeV1, MakeV4, MakeV7} {
+		for i := 0; i < 100; i++ {
+			id := gen()
+			enc := c.Encrypt(id)
+			if enc.Equal(id) {
+				t.Fatalf("%v encrypted to itself", id)
+			}
+			if enc.Version() != id.Version() || enc.Variant() != id.Variant() {
+				t.Fatalf("encryption of %v changed its version or variant: %v", id, enc)
+			}
+			if _, err := Parse(enc.String()); err != nil {
+				t.Fatalf("Parsing of %v failed", enc)
+			}
+			if dec := c.Decrypt(enc); !dec.Equal(id) {
+				t.Fatalf("want %v got %v", id, dec)
+			}
+		}
+	}
+
+	// Consecutive IDs should not stay close together.
+	a := MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	b := MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c073990")
+	if ea, eb := c.Encrypt(a), c.Encrypt(b); ea[0] == eb[0] && ea[1] == eb[1] {
+		t.Fatalf("encryptions of neighbouring IDs are too similar: %v %v", ea, eb)
+	}
+
+	c2, _ := NewCipher([]byte("fedcba9876543210"))
+	if c.Encrypt(a).Equal(c2.Encrypt(a)) {
+		t.Fatal("different keys should give different encryptions")
+	}
+	if _, err := NewCipher([]byte("short")); err == nil {
+		t.Fatal("NewCipher should reject invalid key sizes")
+	}
+}
+
+func BenchmarkCipherEncrypt(b *testing.B) {
+	c, _ := NewCipher([]byte("0123456789abcdef"))
+	id := MakeV7()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.Encrypt(id)
+	}
+}
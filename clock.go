@@ -0,0 +1,23 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// ClockRollbackPolicy selects what a StdGenerator does when the wall clock
+// goes backwards, such as after an NTP step or a VM migration.
+type ClockRollbackPolicy int
+
+const (
+	// HoldTimestamp keeps making UUIDs from the latest timestamp used,
+	// incremented for each UUID, until the clock catches up. UUIDs keep
+	// sorting after those already issued, but their timestamps run ahead
+	// of the clock for a while.
+	HoldTimestamp ClockRollbackPolicy = iota
+
+	// BumpClockSequence uses the clock's time and increments the V1 clock
+	// sequence, as RFC 4122 section 4.2.1 describes. The timestamps are
+	// accurate and the UUIDs unique, but they sort before some already
+	// issued.
+	BumpClockSequence
+)
@@ -0,0 +1,107 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClockRollback(t *testing.T) {
+	now := time.Date(2013, 3, 29, 15, 56, 27, 0, time.UTC)
+	var rollbacks []time.Time
+	g := &StdGenerator{
+		Clock:           func() time.Time { return now },
+		OnClockRollback: func(now, last time.Time) { rollbacks = append(rollbacks, now, last) },
+	}
+	a, _ := g.NewV1()
+	later := now
+	now = now.Add(-time.Second)
+	b, _ := g.NewV1()
+	if len(rollbacks) != 2 || !rollbacks[0].Equal(now) || !rollbacks[1].Equal(later) {
+		t.Fatalf("unexpected rollback notification %v", rollbacks)
+	}
+	if a.v1Time() >= b.v1Time() || !a[8:10].Equal(b[8:10]) {
+		t.Fatalf("HoldTimestamp should keep order: %v %v", a, b)
+	}
+
+	rollbacks = nil
+	now = later
+	g.ClockRollback = BumpClockSequence
+	g.v1LastClock, g.v1LastTime = 0, 0
+	a, _ = g.NewV1()
+	now = now.Add(-time.Second)
+	b, _ = g.NewV1()
+	if len(rollbacks) != 2 {
+		t.Fatalf("unexpected rollback notification %v", rollbacks)
+	}
+	if tm, _ := b.Time(); !tm.Equal(now) {
+		t.Fatalf("want %v got %v", now, tm)
+	}
+	seqA := uint16(a[8]&0x3f)<<8 | uint16(a[9])
+	seqB := uint16(b[8]&0x3f)<<8 | uint16(b[9])
+	if seqB != (seqA+1)&0x3fff {
+		t.Fatalf("clock sequence not bumped: %v %v", a, b)
+	}
+
+	rollbacks = nil
+	now = later
+	g.NewV7()
+	now = now.Add(-time.Second)
+	if id, _ := g.NewV7(); len(rollbacks) != 2 {
+		t.Fatalf("unexpected rollback notification %v for %v", rollbacks, id)
+	}
+}
+
+func TestFixedClock(t *testing.T) {
+	now := time.Date(2013, 3, 29, 15, 56, 27, 0, time.UTC)
+	for _, policy := range []ClockRollbackPolicy{HoldTimestamp, BumpClockSequence} {
+		g := &StdGenerator{
+			Clock:           func() time.Time { return now },
+			ClockRollback:   policy,
+			OnClockRollback: func(now, last time.Time) { t.Fatalf("unexpected rollback from %v to %v", last, now) },
+		}
+		seen := make(map[UuidKey]bool)
+		for i := 0; i < 1<<16; i++ {
+			for _, gen := range []func() (Uuid, error){g.NewV1, g.NewV7} {
+				id, err := gen()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if seen[id.Key()] {
+					t.Fatalf("duplicate UUID %v after %d", id, i)
+				}
+				seen[id.Key()] = true
+			}
+		}
+	}
+}
+
+func TestClockRollbackConcurrent(t *testing.T) {
+	for _, policy := range []ClockRollbackPolicy{HoldTimestamp, BumpClockSequence} {
+		var rollbacks atomic.Int64
+		g := &StdGenerator{
+			ClockRollback:   policy,
+			OnClockRollback: func(now, last time.Time) { rollbacks.Add(1) },
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 2000; j++ {
+					g.NewV1()
+					g.NewV7()
+				}
+			}()
+		}
+		wg.Wait()
+		if n := rollbacks.Load(); n != 0 {
+			t.Fatalf("%d spurious rollbacks with a monotonic clock", n)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Uuid generates, validates and converts UUIDs.
+//
+// Usage:
+//
+//	uuid [gen] [-n count] [-version 1|4|7|comb] [-format name]
+//	uuid parse [id ...]
+//	uuid convert [-format name] [id ...]
+//
+// gen, the default, prints count new UUIDs, one per line. parse prints the
+// canonical form, version, variant and embedded time of each ID, given in
+// any form accepted by uuid.Parse or as a ULID, and exits with status 1 if
+// any is invalid. convert prints each ID in the given format. parse and
+// convert read IDs one per line from standard input if none are given as
+// arguments. The formats are canonical, upper, braced, urn, hex, base64 and
+// ulid.
+//
+// The comb version makes COMB GUIDs for SQL Server. Version 6 is not offered
+// because the package has no Version 6 generator, and neither is Version 8,
+// since uuid.NewKeyed derives it from a secret key and a name rather than
+// generating it.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alberts/uuid"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command with the given arguments and returns the exit
+// status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cmd := "gen"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cmd, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("uuid "+cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var format, version *string
+	var count *int64
+	if cmd == "gen" || cmd == "convert" {
+		format = fs.String("format", "canonical", "output `format`: canonical, upper, braced, urn, hex, base64 or ulid")
+	}
+	if cmd == "gen" {
+		version = fs.String("version", "4", "UUID `version` to generate: 1, 4, 7 or comb")
+		count = fs.Int64("n", 1, "`number` of UUIDs to generate")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	var formatter func([]byte, uuid.Uuid) []byte
+	if format != nil {
+		var ok bool
+		if formatter, ok = formats[*format]; !ok {
+			fmt.Fprintf(stderr, "uuid: unknown format %q\n", *format)
+			return 2
+		}
+	}
+
+	switch cmd {
+	case "gen":
+		gen, ok := generators[*version]
+		if !ok || fs.NArg() > 0 || *count < 0 {
+			fs.Usage()
+			return 2
+		}
+		if err := generate(stdout, gen, formatter, *count); err != nil {
+			fmt.Fprintln(stderr, "uuid:", err)
+			return 1
+		}
+		return 0
+	case "parse":
+		return eachID(fs.Args(), stdin, stderr, func(id uuid.Uuid) {
+			fmt.Fprintf(stdout, "%+v", id)
+			if t, ok := id.Time(); ok {
+				fmt.Fprintf(stdout, " time %s", t.UTC().Format("2006-01-02T15:04:05.9999999Z"))
+			}
+			fmt.Fprintln(stdout)
+		})
+	case "convert":
+		return eachID(fs.Args(), stdin, stderr, func(id uuid.Uuid) {
+			stdout.Write(append(formatter(nil, id), '\n'))
+		})
+	}
+	fmt.Fprintf(stderr, "uuid: unknown command %q\n", cmd)
+	return 2
+}
+
+var generators = map[string]func() (uuid.Uuid, error){
+	"1":    uuid.NewV1,
+	"4":    uuid.NewV4,
+	"7":    uuid.NewV7,
+	"comb": uuid.NewComb,
+}
+
+// formats append an ID to a buffer in each output format.
+var formats = map[string]func([]byte, uuid.Uuid) []byte{
+	"canonical": appendFormat(uuid.FormatCanonical),
+	"braced":    appendFormat(uuid.FormatBraced),
+	"urn":       appendFormat(uuid.FormatURN),
+	"hex":       appendFormat(uuid.FormatHex),
+	"base64":    appendFormat(uuid.FormatBase64),
+	"upper": func(b []byte, id uuid.Uuid) []byte {
+		return append(b, id.UpperString()...)
+	},
+	"ulid": func(b []byte, id uuid.Uuid) []byte {
+		return append(b, id.ToULID()...)
+	},
+}
+
+func appendFormat(format uuid.Format) func([]byte, uuid.Uuid) []byte {
+	return func(b []byte, id uuid.Uuid) []byte {
+		return id.AppendFormat(b, format)
+	}
+}
+
+// generate writes count new IDs to w. Output is buffered and, for more than
+// one ID, the package's random pool is enabled, so that large counts for
+// load tests and fixtures are limited by the output rather than the
+// generator.
+func generate(w io.Writer, gen func() (uuid.Uuid, error), format func([]byte, uuid.Uuid) []byte, count int64) error {
+	if count > 1 {
+		uuid.EnableRandPool()
+		defer uuid.DisableRandPool()
+	}
+	bw := bufio.NewWriterSize(w, 64<<10)
+	var line []byte
+	for ; count > 0; count-- {
+		id, err := gen()
+		if err != nil {
+			return err
+		}
+		line = append(format(line[:0], id), '\n')
+		if _, err := bw.Write(line); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// eachID calls f for each ID in args, or on each line of stdin if args is
+// empty. It reports invalid IDs on stderr and returns the exit status.
+func eachID(args []string, stdin io.Reader, stderr io.Writer, f func(uuid.Uuid)) int {
+	status := 0
+	do := func(s string) {
+		s = strings.TrimSpace(s)
+		id, err := uuid.Parse(s)
+		if err != nil && len(s) == 26 {
+			id, err = uuid.FromULID(s)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "uuid: invalid UUID %q\n", s)
+			status = 1
+			return
+		}
+		f(id)
+	}
+	if len(args) > 0 {
+		for _, arg := range args {
+			do(arg)
+		}
+		return status
+	}
+	sc := bufio.NewScanner(stdin)
+	for sc.Scan() {
+		if strings.TrimSpace(sc.Text()) != "" {
+			do(sc.Text())
+		}
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintln(stderr, "uuid:", err)
+		return 1
+	}
+	return status
+}
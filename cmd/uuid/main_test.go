@@ -0,0 +1,100 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alberts/uuid"
+)
+
+func runString(t *testing.T, stdin string, args ...string) (string, int) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), status
+}
+
+func TestGen(t *testing.T) {
+	for _, version := range []string{"1", "4", "7"} {
+		out, status := runString(t, "", "-version", version)
+		id, err := uuid.Parse(strings.TrimSpace(out))
+		if status != 0 || err != nil || id.Version() != int(version[0]-'0') {
+			t.Fatalf("version %s: unexpected output %q %d", version, out, status)
+		}
+	}
+	out, status := runString(t, "", "-version", "comb")
+	id, err := uuid.Parse(strings.TrimSpace(out))
+	if status != 0 || err != nil || id.Version() != 4 || time.Since(id.CombTime()) > time.Minute {
+		t.Fatalf("comb: unexpected output %q %d", out, status)
+	}
+	if out, status := runString(t, "", "gen", "-format", "hex"); status != 0 || len(out) != 33 {
+		t.Fatalf("unexpected output %q %d", out, status)
+	}
+	for _, args := range [][]string{{"-version", "3"}, {"-version", "6"}, {"-format", "nope"}, {"nope"}, {"parse", "-format", "hex"}} {
+		if _, status := runString(t, "", args...); status != 2 {
+			t.Fatalf("%v: want status 2 got %d", args, status)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	const id = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	out, status := runString(t, "", "parse", strings.ToUpper(id))
+	want := id + " (version 1, variant RFC 4122) time 1998-02-04T22:13:53.1511824Z\n"
+	if status != 0 || out != want {
+		t.Fatalf("want %q got %q %d", want, out, status)
+	}
+	if _, status := runString(t, "", "parse", id, "nope"); status != 1 {
+		t.Fatalf("want status 1 got %d", status)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	in := "f47ac10b-58cc-4372-a567-0e02b2c3d479\n\n{01890A5D-AC96-774B-BCCE-B302099A8057}\n"
+	out, status := runString(t, in, "convert", "-format", "hex")
+	want := "f47ac10b58cc4372a5670e02b2c3d479\n01890a5dac96774bbcceb302099a8057\n"
+	if status != 0 || out != want {
+		t.Fatalf("want %q got %q %d", want, out, status)
+	}
+	ulid, _ := runString(t, "", "convert", "-format", "ulid", "01890a5d-ac96-774b-bcce-b302099a8057")
+	out, _ = runString(t, ulid, "convert")
+	if out != "01890a5d-ac96-774b-bcce-b302099a8057\n" {
+		t.Fatalf("unexpected ULID round trip %q %q", ulid, out)
+	}
+}
+
+func TestGenBulk(t *testing.T) {
+	out, status := runString(t, "", "-n", "1000", "-version", "7", "-format", "base64")
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if status != 0 || len(lines) != 1000 {
+		t.Fatalf("want 1000 lines got %d, status %d", len(lines), status)
+	}
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		if len(line) != 22 || seen[line] {
+			t.Fatalf("unexpected line %q", line)
+		}
+		seen[line] = true
+	}
+	if out, status := runString(t, "", "-n", "0"); status != 0 || out != "" {
+		t.Fatalf("unexpected output %q %d", out, status)
+	}
+	if _, status := runString(t, "", "-n", "-1"); status != 2 {
+		t.Fatalf("want status 2 got %d", status)
+	}
+}
+
+func BenchmarkGen(b *testing.B) {
+	var buf bytes.Buffer
+	gen := generators["4"]
+	b.SetBytes(37 * 1000)
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		generate(&buf, gen, formats["canonical"], 1000)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+// interfaces asserts that the generated types implement the interfaces of
+// uuid.Uuid they are meant to.
+const interfaces = `package shop
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+)
+
+var (
+	_ json.Marshaler           = UserID(nil)
+	_ json.Unmarshaler         = (*UserID)(nil)
+	_ encoding.TextMarshaler   = OrderID(nil)
+	_ encoding.TextUnmarshaler = (*OrderID)(nil)
+	_ sql.Scanner              = (*UserID)(nil)
+	_ driver.Valuer            = OrderID(nil)
+)
+`
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("shop", []string{"UserID", "OrderID"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "shop_uuid.go", src, 0)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, src)
+	}
+	check, err := parser.ParseFile(fset, "interfaces.go", interfaces, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("shop", fset, []*ast.File{f, check}, nil); err != nil {
+		t.Fatalf("generated code does not type-check: %v\n%s", err, src)
+	}
+	if f.Name.Name != "shop" {
+		t.Fatalf("want package shop got %s", f.Name.Name)
+	}
+	methods := make(map[string]int)
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+			methods[fn.Name.Name]++
+		}
+	}
+	for _, name := range []string{"String", "MarshalJSON", "UnmarshalJSON", "MarshalText", "UnmarshalText", "Scan", "Value", "Marshal", "Unmarshal"} {
+		if methods[name] != 2 {
+			t.Fatalf("want 2 %s methods got %d", name, methods[name])
+		}
+	}
+	if obj := f.Scope.Lookup("NewOrderID"); obj == nil {
+		t.Fatal("NewOrderID not declared")
+	}
+
+	for _, types := range [][]string{{"userID"}, {"User ID"}, {""}} {
+		if _, err := generate("shop", types); err == nil {
+			t.Fatalf("%q should fail", types)
+		}
+	}
+	if _, err := generate("my-shop", []string{"UserID"}); err == nil {
+		t.Fatal("invalid package name should fail")
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// NewComb makes a COMB GUID: a Version 4 UUID whose last six bytes hold
+// the big-endian Unix time in milliseconds. SQL Server compares
+// uniqueidentifier values starting with those bytes, followed by bytes 8
+// and 9, so COMBs inserted into a clustered index append to its end like
+// NEWSEQUENTIALID values instead of splitting pages. Bytes 8 and 9 hold a
+// counter, shared with NewV7, that orders COMBs made in the same
+// millisecond. The other 74 bits are random.
+//
+// COMBs sort by time only in SQL Server's order; use V7 UUIDs with other
+// databases.
+func (g *StdGenerator) NewComb() (Uuid, error) {
+	id := make(Uuid, 16)
+	if err := g.read(id); err != nil {
+		return nil, err
+	}
+	ms, seq := g.nextV7()
+	putComb(id, ms, seq)
+	countGenerated(4)
+	return id, nil
+}
+
+// putComb sets the version, timestamp and counter of a COMB GUID. The 12-bit
+// counter follows the variant bits, leaving the low 2 bits of byte 9 random.
+func putComb(id Uuid, ms int64, seq uint16) {
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(ms))
+	copy(id[10:], ts[2:])
+	id[6] = (id[6] & 0xf) | 0x40
+	id[8] = 0x80 | byte(seq>>6)&0x3f
+	id[9] = byte(seq<<2) | id[9]&0x03
+}
+
+// NewComb makes a COMB GUID with the default generator. If the default
+// generator has no NewComb method, as StdGenerator does, the COMB is made
+// from its NewV4 and the current time, without a counter.
+func NewComb() (Uuid, error) {
+	gen := defaultGenerator()
+	if g, ok := gen.(interface{ NewComb() (Uuid, error) }); ok {
+		return g.NewComb()
+	}
+	id, err := gen.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	putComb(id, time.Now().UnixMilli(), uint16(id[8])<<6|uint16(id[9])>>2)
+	return id, nil
+}
+
+// MakeComb makes a COMB GUID with the default generator. See NewComb.
+func MakeComb() Uuid {
+	return mustMake(NewComb())
+}
+
+// CombTime returns the time stored in the last six bytes of a COMB GUID.
+// The result is meaningless for other UUIDs.
+func (uuid Uuid) CombTime() time.Time {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	var ts [8]byte
+	copy(ts[2:], uuid[10:])
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(ts[:])))
+}
@@ -0,0 +1,73 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// sqlServerOrder lists the byte positions of a UUID in the order SQL Server
+// compares uniqueidentifier values.
+var sqlServerOrder = []int{10, 11, 12, 13, 14, 15, 8, 9, 6, 7, 4, 5, 3, 2, 1, 0}
+
+func sqlServerLess(a, b Uuid) bool {
+	for _, i := range sqlServerOrder {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func TestComb(t *testing.T) {
+	now := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	g := &StdGenerator{
+		Clock: func() time.Time { return now },
+		Rand:  bytes.NewReader(bytes.Repeat([]byte{0xff}, 32)),
+	}
+	id, err := g.NewComb()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := id.String(); s != "ffffffff-ffff-4fff-8003-017f22e279b0" {
+		t.Fatalf("unexpected COMB %s", s)
+	}
+	if tm := id.CombTime(); !tm.Equal(now) {
+		t.Fatalf("want %v got %v", now, tm)
+	}
+
+	g = new(StdGenerator)
+	prev, _ := g.NewComb()
+	for i := 0; i < 10000; i++ {
+		id, err := g.NewComb()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.Version() != 4 || id.Variant() != VariantRFC4122 {
+			t.Fatalf("invalid COMB %v", id)
+		}
+		if !sqlServerLess(prev, id) {
+			t.Fatalf("%v should sort after %v in SQL Server", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestNewComb(t *testing.T) {
+	defer SetDefaultGenerator(defaultGenerator())
+	before := time.Now().Truncate(time.Millisecond)
+	for _, g := range []Generator{new(StdGenerator), new(SequentialGenerator)} {
+		SetDefaultGenerator(g)
+		id := MakeComb()
+		if id.Version() != 4 || id.Variant() != VariantRFC4122 {
+			t.Fatalf("invalid COMB %v", id)
+		}
+		if tm := id.CombTime(); tm.Before(before) || tm.After(time.Now()) {
+			t.Fatalf("unexpected COMB time %v", tm)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// FromArray converts any 16-byte array type to a Uuid. This covers the UUID
+// types of other packages, such as github.com/google/uuid and
+// github.com/gofrs/uuid:
+//
+//	id := uuid.FromArray(googleuuid.New())
+//	id := uuid.FromArray(gofrsuuid.Must(gofrsuuid.NewV4()))
+func FromArray[A ~[16]byte](a A) Uuid {
+	uuid := Make()
+	copy(uuid, a[:])
+	return uuid
+}
+
+// ToArray converts uuid to any 16-byte array type, such as the UUID types of
+// github.com/google/uuid and github.com/gofrs/uuid:
+//
+//	g := uuid.ToArray[googleuuid.UUID](id)
+//	f := uuid.ToArray[gofrsuuid.UUID](id)
+func ToArray[A ~[16]byte](uuid Uuid) A {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	var a A
+	copy(a[:], uuid)
+	return a
+}
+
+// TraceID returns the 128 bits of uuid as an OpenTelemetry trace ID. The
+// result is assignable to trace.TraceID of go.opentelemetry.io/otel/trace.
+// The Nil UUID maps to the invalid all-zero trace ID.
+func (uuid Uuid) TraceID() [16]byte {
+	return ToArray[[16]byte](uuid)
+}
+
+// SpanID returns the low 64 bits of uuid as an OpenTelemetry span ID. The
+// result is assignable to trace.SpanID of go.opentelemetry.io/otel/trace.
+func (uuid Uuid) SpanID() [8]byte {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	var id [8]byte
+	copy(id[:], uuid[8:])
+	return id
+}
+
+// FromTraceID converts an OpenTelemetry trace ID to a Uuid. A trace.TraceID
+// can be passed directly.
+func FromTraceID(id [16]byte) Uuid {
+	return FromArray(id)
+}
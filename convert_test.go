@@ -0,0 +1,71 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+)
+
+// googleUUID has the same definition as uuid.UUID in github.com/google/uuid.
+type googleUUID [16]byte
+
+// gofrsUUID has the same definition as uuid.UUID in github.com/gofrs/uuid.
+type gofrsUUID [gofrsSize]byte
+
+const gofrsSize = 16
+
+func TestArrayGoogle(t *testing.T) {
+	id := MakeV4()
+	g := ToArray[googleUUID](id)
+	if string(g[:]) != string(id) {
+		t.Fatalf("want %x got %x", []byte(id), g)
+	}
+	id2 := FromArray(g)
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+	g[0]++
+	if id2[0] == g[0] {
+		t.Fatal("FromArray should copy its input")
+	}
+	if key := ToArray[UuidKey](id); key != id.Key() {
+		t.Fatalf("want %v got %v", id.Key(), key)
+	}
+}
+
+func TestArrayGofrs(t *testing.T) {
+	id := MakeV4()
+	f := ToArray[gofrsUUID](id)
+	if string(f[:]) != string(id) {
+		t.Fatalf("want %x got %x", []byte(id), f)
+	}
+	if id2 := FromArray(f); !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+	// Values convert between the two libraries through Uuid losslessly.
+	if g := ToArray[googleUUID](FromArray(f)); string(g[:]) != string(f[:]) {
+		t.Fatalf("want %x got %x", f, g)
+	}
+}
+
+// traceID and spanID have the same definitions as trace.TraceID and
+// trace.SpanID in go.opentelemetry.io/otel/trace.
+type traceID [16]byte
+type spanID [8]byte
+
+func TestTraceID(t *testing.T) {
+	id := MakeV4()
+	var tid traceID = id.TraceID()
+	var sid spanID = id.SpanID()
+	if string(tid[:]) != string(id) {
+		t.Fatalf("want %x got %x", []byte(id), tid)
+	}
+	if string(sid[:]) != string(id[8:]) {
+		t.Fatalf("want %x got %x", []byte(id[8:]), sid)
+	}
+	if id2 := FromTraceID(tid); !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+var errInvalidLength = errors.New("uuid: invalid length")
+
+// AppendText implements encoding.TextAppender. It appends the same
+// representation as String without allocating.
+func (uuid Uuid) AppendText(b []byte) ([]byte, error) {
+	if len(uuid) == 0 {
+		return append(b, emptyString()...), nil
+	}
+	if len(uuid) != 16 {
+		return b, errInvalidLength
+	}
+	return uuid.appendCanonical(b), nil
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the 16 raw
+// bytes of uuid, or nothing for an empty Uuid.
+//
+// Uuid and UuidKey do not implement encoding.BinaryMarshaler or
+// gob.GobEncoder on purpose: encoding/gob would then change their wire
+// types and could no longer decode streams that hold them as a plain byte
+// slice and array. gob already sends a Uuid as its raw bytes.
+func (uuid Uuid) AppendBinary(b []byte) ([]byte, error) {
+	if len(uuid) != 0 && len(uuid) != 16 {
+		return b, errInvalidLength
+	}
+	return append(b, uuid...), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (uuid Uuid) MarshalText() ([]byte, error) {
+	return uuid.AppendText(make([]byte, 0, 36))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts any form
+// understood by Parse. Empty text decodes to an empty Uuid.
+func (uuid *Uuid) UnmarshalText(text []byte) error {
+	s := string(text)
+	if s == "" || s == "<empty uuid>" {
+		*uuid = nil
+		return nil
+	}
+	id, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*uuid = id
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, writing the canonical form.
+func (key UuidKey) MarshalText() ([]byte, error) {
+	return key.Uuid().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts anything
+// Uuid.UnmarshalText does; the empty string gives the zero key.
+func (key *UuidKey) UnmarshalText(data []byte) error {
+	var id Uuid
+	if err := id.UnmarshalText(data); err != nil {
+		return err
+	}
+	return key.set(id)
+}
+
+// MarshalXML implements xml.Marshaler. The UUID is written as the character
+// data of the element, an empty Uuid as an empty element.
+func (uuid Uuid) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(uuid) == 0 {
+		return e.EncodeElement("", start)
+	}
+	if len(uuid) != 16 {
+		return errInvalidLength
+	}
+	return e.EncodeElement(uuid.String(), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (uuid *Uuid) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return uuid.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr. An empty Uuid omits the
+// attribute.
+func (uuid Uuid) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if len(uuid) == 0 {
+		return xml.Attr{}, nil
+	}
+	if len(uuid) != 16 {
+		return xml.Attr{}, errInvalidLength
+	}
+	return xml.Attr{Name: name, Value: uuid.String()}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (uuid *Uuid) UnmarshalXMLAttr(attr xml.Attr) error {
+	return uuid.UnmarshalText([]byte(attr.Value))
+}
@@ -0,0 +1,211 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/xml"
+	"testing"
+)
+
+var (
+	_ encoding.TextAppender    = Uuid(nil)
+	_ encoding.BinaryAppender  = Uuid(nil)
+	_ encoding.TextMarshaler   = Uuid(nil)
+	_ encoding.TextUnmarshaler = (*Uuid)(nil)
+	_ xml.Marshaler            = Uuid(nil)
+	_ xml.Unmarshaler          = (*Uuid)(nil)
+	_ xml.MarshalerAttr        = Uuid(nil)
+	_ xml.UnmarshalerAttr      = (*Uuid)(nil)
+	_ encoding.TextMarshaler   = UuidKey{}
+	_ encoding.TextUnmarshaler = (*UuidKey)(nil)
+)
+
+func TestAppendText(t *testing.T) {
+	id := MakeV4()
+	b, err := id.AppendText([]byte("id="))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "id=" + id.String(); string(b) != want {
+		t.Fatalf("want %s got %s", want, b)
+	}
+	b, err = Uuid(nil).AppendText(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != Uuid(nil).String() {
+		t.Fatalf("want %s got %s", Uuid(nil).String(), b)
+	}
+	if _, err := Uuid(make([]byte, 3)).AppendText(nil); err != errInvalidLength {
+		t.Fatalf("want %v got %v", errInvalidLength, err)
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	id := MakeV4()
+	b, err := id.AppendBinary([]byte{0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 17 || b[0] != 0xff || !bytes.Equal(b[1:], id) {
+		t.Fatalf("unexpected encoding %x", b)
+	}
+	if _, err := Uuid(make([]byte, 20)).AppendBinary(nil); err != errInvalidLength {
+		t.Fatalf("want %v got %v", errInvalidLength, err)
+	}
+}
+
+func TestAppendTextAllocs(t *testing.T) {
+	id := MakeV4()
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = id.AppendText(buf[:0])
+		buf, _ = id.AppendBinary(buf)
+	})
+	if allocs != 0 {
+		t.Fatalf("want 0 allocs got %v", allocs)
+	}
+}
+
+func TestText(t *testing.T) {
+	id := MakeV4()
+	text, err := id.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != id.String() {
+		t.Fatalf("want %s got %s", id.String(), text)
+	}
+	var id2 Uuid
+	if err := id2.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+	if err := id2.UnmarshalText(nil); err != nil || id2 != nil {
+		t.Fatalf("empty text should decode to an empty Uuid, got %v, %v", id2, err)
+	}
+	if err := id2.UnmarshalText([]byte("9b78d54c")); err != errParseFailed {
+		t.Fatalf("want %v got %v", errParseFailed, err)
+	}
+}
+
+type myXMLStruct struct {
+	XMLName xml.Name `xml:"ref"`
+	Attr    Uuid     `xml:"attr,attr"`
+	Elem    Uuid     `xml:"elem"`
+}
+
+func TestKeyText(t *testing.T) {
+	key := MakeV4().Key()
+	b, err := key.MarshalText()
+	if err != nil || string(b) != key.String() {
+		t.Fatalf("want %v got %s %v", key, b, err)
+	}
+	var got UuidKey
+	if err := got.UnmarshalText(b); err != nil || got != key {
+		t.Fatalf("want %v got %v %v", key, got, err)
+	}
+	if err := got.UnmarshalText(nil); err != nil || got != (UuidKey{}) {
+		t.Fatalf("want zero key got %v %v", got, err)
+	}
+	if err := got.UnmarshalText([]byte("nope")); err == nil {
+		t.Fatal("invalid text should fail")
+	}
+}
+
+func TestXML(t *testing.T) {
+	m := &myXMLStruct{Attr: MakeV4(), Elem: MakeV4()}
+	data, err := xml.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &myXMLStruct{}
+	if err := xml.Unmarshal(data, u); err != nil {
+		t.Fatal(err)
+	}
+	if !m.Attr.Equal(u.Attr) || !m.Elem.Equal(u.Elem) {
+		t.Fatalf("want %v got %v", m, u)
+	}
+
+	data, err = xml.Marshal(&myXMLStruct{Elem: m.Elem})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "<ref><elem>" + m.Elem.String() + "</elem></ref>"; string(data) != expected {
+		t.Fatalf("want %s got %s", expected, data)
+	}
+	if err := xml.Unmarshal([]byte(`<ref attr="bogus"></ref>`), u); err != errParseFailed {
+		t.Fatalf("want %v got %v", errParseFailed, err)
+	}
+}
+
+type myGobStruct struct {
+	Id   Uuid
+	Key  UuidKey
+	Keys map[UuidKey]Uuid
+}
+
+func TestGob(t *testing.T) {
+	id := MakeV4()
+	m := &myGobStruct{
+		Id:   id,
+		Key:  MakeV4().Key(),
+		Keys: map[UuidKey]Uuid{id.Key(): id},
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), id) {
+		t.Fatal("gob should encode the 16 raw bytes")
+	}
+	u := &myGobStruct{}
+	if err := gob.NewDecoder(&buf).Decode(u); err != nil {
+		t.Fatal(err)
+	}
+	if !u.Id.Equal(m.Id) || u.Key != m.Key || !u.Keys[id.Key()].Equal(id) {
+		t.Fatalf("want %v got %v", m, u)
+	}
+}
+
+// baselineGob is myGobStruct as encoded before Uuid and UuidKey had any
+// encoding methods, with Id 9b78d54c-8cc9-46bc-ae29-efcba10e1abb and Key
+// 6ccd780c-baba-4026-9564-5b8c656024db.
+const baselineGob = "327f0301010b6d79476f6253747275637401ff8000010301024964010a0001034b65" +
+	"7901ff820001044b65797301ff8400000017ff8101010107557569644b657901ff8200" +
+	"0106012000002bff830401011a6d61705b757569642e557569644b65795d757569642e" +
+	"5575696401ff840001ff82010a000057ff8001109b78d54c8cc946bcae29efcba10e1a" +
+	"bb01106cffcd780cffbaffba4026ff95645bff8c656024ffdb0101106cffcd780cffba" +
+	"ffba4026ff95645bff8c656024ffdb109b78d54c8cc946bcae29efcba10e1abb00"
+
+func TestGobBaseline(t *testing.T) {
+	data, err := hex.DecodeString(baselineGob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m myGobStruct
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	key := MustParse("6ccd780c-baba-4026-9564-5b8c656024db").Key()
+	if !m.Id.Equal(id) || m.Key != key || !m.Keys[key].Equal(id) {
+		t.Fatalf("unexpected decoding %v", m)
+	}
+	for _, v := range []interface{}{new(Uuid), new(UuidKey)} {
+		if _, ok := v.(encoding.BinaryUnmarshaler); ok {
+			t.Fatalf("%T should not change its gob wire type", v)
+		}
+		if _, ok := v.(gob.GobDecoder); ok {
+			t.Fatalf("%T should not change its gob wire type", v)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// Set implements flag.Value, so a *Uuid can be registered with flag.Var. It
+// accepts any form understood by Parse.
+func (uuid *Uuid) Set(s string) error {
+	id, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*uuid = id
+	return nil
+}
+
+// Type returns the type name shown in usage messages by
+// github.com/spf13/pflag.
+func (uuid *Uuid) Type() string {
+	return "uuid"
+}
@@ -0,0 +1,30 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+var _ flag.Value = (*Uuid)(nil)
+
+func TestFlag(t *testing.T) {
+	id := MakeV4()
+	var id2 Uuid
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&id2, "id", "the id")
+	if err := fs.Parse([]string{"-id", id.String()}); err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+	if err := fs.Parse([]string{"-id", "bogus"}); err == nil {
+		t.Fatal("Parsing of a malformed flag should have failed")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const urnPrefix = "urn:uuid:"
+
+// Format selects a textual representation for AppendFormat.
+type Format int
+
+const (
+	FormatCanonical Format = iota // xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+	FormatBraced                  // {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}
+	FormatURN                     // urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+	FormatHex                     // xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
+	FormatBase64                  // 22 characters of unpadded URL-safe base64
+	FormatBinary                  // the 16 raw bytes
+)
+
+// AppendFormat appends the representation of uuid selected by format to dst
+// and returns the extended buffer. It does not allocate if dst has enough
+// capacity.
+func (uuid Uuid) AppendFormat(dst []byte, format Format) []byte {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	switch format {
+	case FormatCanonical:
+		return uuid.appendCanonical(dst)
+	case FormatBraced:
+		dst = uuid.appendCanonical(append(dst, '{'))
+		return append(dst, '}')
+	case FormatURN:
+		return uuid.appendCanonical(append(dst, urnPrefix...))
+	case FormatHex:
+		return uuid.appendHex(dst, hexPairs, false)
+	case FormatBase64:
+		return base64.RawURLEncoding.AppendEncode(dst, uuid)
+	case FormatBinary:
+		return append(dst, uuid...)
+	}
+	panic("uuid: unknown format")
+}
+
+// URN returns the RFC 4122 URN form of uuid, "urn:uuid:" followed by the
+// canonical string.
+func (uuid Uuid) URN() string {
+	return string(uuid.AppendFormat(make([]byte, 0, 45), FormatURN))
+}
+
+// Braced returns the canonical form of uuid enclosed in curly braces, as
+// used by the Windows registry, COM and SQL Server tooling.
+func (uuid Uuid) Braced() string {
+	return string(uuid.AppendFormat(make([]byte, 0, 38), FormatBraced))
+}
+
+// UpperString returns the canonical form of uuid with upper-case hex digits.
+func (uuid Uuid) UpperString() string {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	return string(uuid.appendHex(make([]byte, 0, 36), hexPairsUpper, true))
+}
+
+// HexString returns the 32 hex digits of uuid without hyphens.
+func (uuid Uuid) HexString() string {
+	return string(uuid.AppendFormat(make([]byte, 0, 32), FormatHex))
+}
+
+// RedactLogs makes Format and LogValue print Redacted instead of the full
+// UUID, for environments where IDs are considered sensitive in logs. String
+// and the marshaling methods are not affected.
+var RedactLogs = false
+
+// Redacted returns the canonical form of uuid with all but the first 8 and
+// last 4 hex digits masked, such as "9b78d54c-****-****-****-********1abb".
+// That is enough to correlate log lines but not to use the ID.
+func (uuid Uuid) Redacted() string {
+	if len(uuid) != 16 {
+		return uuid.String()
+	}
+	var buf [36]byte
+	b := uuid.appendCanonical(buf[:0])
+	for i := 9; i < 32; i++ {
+		if b[i] != '-' {
+			b[i] = '*'
+		}
+	}
+	return string(b)
+}
+
+// Format implements fmt.Formatter. The verbs %s and %v print the canonical
+// form, %q the quoted canonical form, %x and %X the hex digits without
+// hyphens in lower and upper case, %+v the canonical form followed by the
+// decoded version and variant, and %#v a Go expression. If RedactLogs is
+// set, every verb prints the Redacted form.
+func (uuid Uuid) Format(f fmt.State, verb rune) {
+	if RedactLogs && len(uuid) == 16 {
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), uuid.Redacted())
+		return
+	}
+	if len(uuid) != 16 {
+		if verb == 'v' && f.Flag('#') {
+			if len(uuid) == 0 {
+				io.WriteString(f, "uuid.Uuid(nil)")
+				return
+			}
+			// Print the bytes as a composite literal, like []byte{0x1, 0x2}.
+			io.WriteString(f, "uuid.Uuid"+strings.TrimPrefix(fmt.Sprintf("%#v", []byte(uuid)), "[]byte"))
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), uuid.String())
+		return
+	}
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			fmt.Fprintf(f, "uuid.MustParse(%q)", uuid.String())
+		case f.Flag('+'):
+			fmt.Fprintf(f, "%s (version %d, variant %s)", uuid.String(), uuid.Version(), variantNames[uuid.Variant()])
+		default:
+			fmt.Fprintf(f, fmt.FormatString(f, 's'), uuid.String())
+		}
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), uuid.String())
+	case 'x':
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), uuid.appendHex(make([]byte, 0, 32), hexPairs, false))
+	case 'X':
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), uuid.appendHex(make([]byte, 0, 32), hexPairsUpper, false))
+	default:
+		fmt.Fprintf(f, "%%!%c(uuid.Uuid=%s)", verb, uuid.String())
+	}
+}
+
+// LogValue implements slog.LogValuer, so structured logs record the
+// canonical string rather than a byte array, or the Redacted form if
+// RedactLogs is set.
+func (uuid Uuid) LogValue() slog.Value {
+	if RedactLogs {
+		return slog.StringValue(uuid.Redacted())
+	}
+	return slog.StringValue(uuid.String())
+}
+
+// LogValue implements slog.LogValuer.
+func (key UuidKey) LogValue() slog.Value {
+	return key.Uuid().LogValue()
+}
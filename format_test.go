@@ -0,0 +1,204 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestURN(t *testing.T) {
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	const expected = "urn:uuid:9b78d54c-8cc9-46bc-ae29-efcba10e1abb"
+	if actual := id.URN(); actual != expected {
+		t.Fatalf("strings not equal: expected: %v, actual: %v", expected, actual)
+	}
+	for _, str := range []string{expected, "URN:UUID:9b78d54c-8cc9-46bc-ae29-efcba10e1abb"} {
+		id2, err := Parse(str)
+		if err != nil {
+			t.Fatalf("Parsing of %s failed: %v", str, err)
+		}
+		if !id2.Equal(id) {
+			t.Fatalf("want %v got %v", id, id2)
+		}
+	}
+	if _, err := Parse("urn:uid:{9b78d54c-8cc9-46bc-ae29-efcba10e1abb"); err != errParseFailed {
+		t.Fatal("Parsing of a malformed URN should have failed")
+	}
+}
+
+func TestBraced(t *testing.T) {
+	id := MakeV4()
+	actual := id.Braced()
+	if expected := "{" + id.String() + "}"; actual != expected {
+		t.Fatalf("strings not equal: expected: %v, actual: %v", expected, actual)
+	}
+	id2, err := Parse(actual)
+	if err != nil {
+		t.Fatalf("Parsing of %s failed: %v", actual, err)
+	}
+	if !id2.Equal(id) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+}
+
+func TestUpperString(t *testing.T) {
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	const expected = "9B78D54C-8CC9-46BC-AE29-EFCBA10E1ABB"
+	actual := id.UpperString()
+	if actual != expected {
+		t.Fatalf("strings not equal: expected: %v, actual: %v", expected, actual)
+	}
+	if !MustParse(actual).Equal(id) {
+		t.Fatal("UUIDs are not equal")
+	}
+}
+
+func TestHexString(t *testing.T) {
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	const expected = "9b78d54c8cc946bcae29efcba10e1abb"
+	actual := id.HexString()
+	if actual != expected {
+		t.Fatalf("strings not equal: expected: %v, actual: %v", expected, actual)
+	}
+	id2, err := Parse(actual)
+	if err != nil {
+		t.Fatalf("Parsing of %s failed: %v", actual, err)
+	}
+	if !id2.Equal(id) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+	bad := []string{
+		"9b78d54c8cc946bcae29efcba10e1ab",
+		"9b78d54c-8cc946bcae29efcba10e1abb",
+		"9b78d54c8cc946bcae29efcba10e1abX",
+		"{9b78d54c8cc946bcae29efcba10e1abb}",
+	}
+	for _, str := range bad {
+		if _, err := Parse(str); err != errParseFailed {
+			t.Fatalf("Parsing of %s should have failed", str)
+		}
+	}
+}
+
+func TestAppendFormat(t *testing.T) {
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{FormatCanonical, "9b78d54c-8cc9-46bc-ae29-efcba10e1abb"},
+		{FormatBraced, "{9b78d54c-8cc9-46bc-ae29-efcba10e1abb}"},
+		{FormatURN, "urn:uuid:9b78d54c-8cc9-46bc-ae29-efcba10e1abb"},
+		{FormatHex, "9b78d54c8cc946bcae29efcba10e1abb"},
+		{FormatBase64, "m3jVTIzJRryuKe_LoQ4auw"},
+		{FormatBinary, string(id)},
+	}
+	for _, test := range tests {
+		actual := string(id.AppendFormat([]byte("id="), test.format))
+		if actual != "id="+test.expected {
+			t.Fatalf("format %d: expected: %v, actual: %v", test.format, test.expected, actual)
+		}
+	}
+}
+
+func TestAppendFormatAllocs(t *testing.T) {
+	id := MakeV4()
+	buf := make([]byte, 0, 64)
+	for format := FormatCanonical; format <= FormatBinary; format++ {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = id.AppendFormat(buf[:0], format)
+		})
+		if allocs != 0 {
+			t.Fatalf("format %d: want 0 allocs got %v", format, allocs)
+		}
+	}
+}
+
+func BenchmarkAppendFormat(b *testing.B) {
+	id := MakeV4()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = id.AppendFormat(buf[:0], FormatCanonical)
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%s", "9b78d54c-8cc9-46bc-ae29-efcba10e1abb"},
+		{"%v", "9b78d54c-8cc9-46bc-ae29-efcba10e1abb"},
+		{"%q", `"9b78d54c-8cc9-46bc-ae29-efcba10e1abb"`},
+		{"%x", "9b78d54c8cc946bcae29efcba10e1abb"},
+		{"%X", "9B78D54C8CC946BCAE29EFCBA10E1ABB"},
+		{"%+v", "9b78d54c-8cc9-46bc-ae29-efcba10e1abb (version 4, variant RFC 4122)"},
+		{"%#v", `uuid.MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")`},
+		{"%40s|", "    9b78d54c-8cc9-46bc-ae29-efcba10e1abb|"},
+		{"%-40s|", "9b78d54c-8cc9-46bc-ae29-efcba10e1abb    |"},
+		{"%d", "%!d(uuid.Uuid=9b78d54c-8cc9-46bc-ae29-efcba10e1abb)"},
+	}
+	for _, test := range tests {
+		if actual := fmt.Sprintf(test.format, id); actual != test.expected {
+			t.Fatalf("%s: expected: %v, actual: %v", test.format, test.expected, actual)
+		}
+	}
+	if actual := fmt.Sprintf("%v", Uuid(nil)); actual != Uuid(nil).String() {
+		t.Fatalf("expected: %v, actual: %v", Uuid(nil).String(), actual)
+	}
+	if actual := fmt.Sprintf("%#v", Uuid(nil)); actual != "uuid.Uuid(nil)" {
+		t.Fatalf("expected: uuid.Uuid(nil), actual: %v", actual)
+	}
+	if actual := fmt.Sprintf("%#v", Uuid{1, 0xab, 3}); actual != "uuid.Uuid{0x1, 0xab, 0x3}" {
+		t.Fatalf("expected: uuid.Uuid{0x1, 0xab, 0x3}, actual: %v", actual)
+	}
+	if actual := fmt.Sprintf("%v", []Uuid{id}); actual != "[9b78d54c-8cc9-46bc-ae29-efcba10e1abb]" {
+		t.Fatalf("unexpected slice formatting: %v", actual)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	id := MakeV4()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("msg", "id", id, "key", id.Key())
+	expected := `"id":"` + id.String() + `","key":"` + id.String() + `"`
+	if !strings.Contains(buf.String(), expected) {
+		t.Fatalf("want %s in %s", expected, buf.String())
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	const want = "9b78d54c-****-****-****-********1abb"
+	if s := id.Redacted(); s != want {
+		t.Fatalf("want %s got %s", want, s)
+	}
+	if s := fmt.Sprintf("%v", id); s != id.String() {
+		t.Fatalf("want %v got %s", id, s)
+	}
+
+	defer func(r bool) { RedactLogs = r }(RedactLogs)
+	RedactLogs = true
+	for _, verb := range []string{"%v", "%s", "%+v", "%x"} {
+		if s := fmt.Sprintf(verb, id); s != want {
+			t.Fatalf("%s: want %s got %s", verb, want, s)
+		}
+	}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("msg", "id", id, "cached", NewCached(id))
+	if expected := `"id":"` + want + `","cached":"` + want + `"`; !strings.Contains(buf.String(), expected) {
+		t.Fatalf("want %s in %s", expected, buf.String())
+	}
+	if id.String() != "9b78d54c-8cc9-46bc-ae29-efcba10e1abb" {
+		t.Fatal("String should not be redacted")
+	}
+}
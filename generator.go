@@ -0,0 +1,160 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Generator makes new UUIDs. Implementations must be safe for concurrent
+// use. The package-level MakeV1, MakeV4 and MakeV7 functions delegate to a
+// default StdGenerator; applications can pass a Generator through their
+// dependency graph to substitute deterministic, audited or hardware-backed
+// implementations.
+type Generator interface {
+	NewV1() (Uuid, error)
+	NewV4() (Uuid, error)
+	NewV7() (Uuid, error)
+}
+
+// StdGenerator is the standard Generator. Each StdGenerator keeps its own
+// clock sequence, node ID and counters, so the time-based UUIDs it makes
+// are ordered. The zero value is ready to use.
+type StdGenerator struct {
+	// Rand is the source of random bits, such as crypto/rand.Reader, a
+	// hardware RNG or a recorded stream for reproducible output. It must be
+	// safe for concurrent use if the generator is. If Rand is nil, the
+	// package's AES-CTR stream is used.
+	Rand io.Reader
+
+	// Clock returns the current time for time-based UUIDs. If Clock is nil,
+	// time.Now is used.
+	Clock func() time.Time
+
+	// Node is the 6-byte node ID for V1 UUIDs, such as an IEEE 802 MAC
+	// address. If Node is nil, a random node ID is chosen.
+	Node []byte
+
+	// ClockRollback selects how V1 UUIDs are made after the clock goes
+	// backwards.
+	ClockRollback ClockRollbackPolicy
+
+	// OnClockRollback, if set, is called when the clock reads earlier than
+	// its previous reading, with both times. Timestamps advanced ahead of
+	// the clock because UUIDs are made faster than the timestamp
+	// resolution allows do not count. It may be called with a lock held, so
+	// it must not make UUIDs with the same generator.
+	OnClockRollback func(now, last time.Time)
+
+	v1Lock      sync.Mutex
+	v1LastClock uint64
+	v1LastTime  uint64
+	v1ClockSeq  uint16
+	v1Node      []byte
+	v1Reseed    uint64
+
+	v7State     atomic.Uint64
+	v7LastClock atomic.Uint64
+}
+
+// defaultGen points to the generator of the package-level functions. It is
+// atomic so that SetDefaultGenerator may race with making UUIDs.
+var defaultGen atomic.Pointer[Generator]
+
+func init() {
+	SetDefaultGenerator(nil)
+}
+
+// defaultGenerator returns the generator set by SetDefaultGenerator.
+func defaultGenerator() Generator {
+	return *defaultGen.Load()
+}
+
+// SetDefaultGenerator replaces the generator used by the package-level
+// MakeV1, MakeV4, MakeV7, MakeComb and NewV1, NewV4, NewV7, NewComb
+// functions, such as a StdGenerator with a different entropy source, clock
+// or node ID. It is safe to call while other goroutines make UUIDs, which
+// use either the old or the new generator. A nil g restores a new
+// StdGenerator.
+func SetDefaultGenerator(g Generator) {
+	if g == nil {
+		g = new(StdGenerator)
+	}
+	defaultGen.Store(&g)
+}
+
+var errInvalidNode = errors.New("uuid: node ID must be 6 bytes")
+
+// mustMake panics if a default generator fails; the Make functions have no
+// other way to report errors.
+func mustMake(uuid Uuid, err error) Uuid {
+	if err != nil {
+		panic(err)
+	}
+	return uuid
+}
+
+// now returns the current time from the generator's clock.
+func (g *StdGenerator) now() time.Time {
+	if g.Clock != nil {
+		return g.Clock()
+	}
+	return time.Now()
+}
+
+// read fills b with random bytes.
+func (g *StdGenerator) read(b []byte) error {
+	if g.Rand != nil {
+		_, err := io.ReadFull(g.Rand, b)
+		return err
+	}
+	return readStream(b)
+}
+
+// NewV4 makes a Version 4 (random data based) UUID.
+func (g *StdGenerator) NewV4() (Uuid, error) {
+	// V4 UUID is of the form: xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx
+	// where x is any hexadecimal digit and y is one of 8, 9, A, or B.
+	id := make(Uuid, 16)
+	if err := g.read(id); err != nil {
+		return nil, err
+	}
+
+	// Set the four most significant bits (bits 12 through 15) of the
+	// time_hi_and_version field to the 4-bit version number from
+	// Section 4.1.3.
+	id[6] = (id[6] & 0xf) | 0x40
+
+	// Set the two most significant bits (bits 6 and 7) of the
+	// clock_seq_hi_and_reserved to zero and one, respectively.
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	countGenerated(4)
+	return id, nil
+}
+
+// SequentialGenerator is a Generator for tests and fixtures. It makes
+// easily readable UUIDs numbered from 1 with the version of the method
+// called, such as 00000000-0000-4000-8000-000000000001,
+// 00000000-0000-4000-8000-000000000002 and so on. All versions share one
+// counter. The zero value is ready to use.
+type SequentialGenerator struct {
+	n atomic.Uint64
+}
+
+func (g *SequentialGenerator) next(version byte) (Uuid, error) {
+	id := FromUint64Pair(0, g.n.Add(1))
+	id[6] |= version << 4
+	id[8] |= 0x80
+	return id, nil
+}
+
+func (g *SequentialGenerator) NewV1() (Uuid, error) { return g.next(1) }
+func (g *SequentialGenerator) NewV4() (Uuid, error) { return g.next(4) }
+func (g *SequentialGenerator) NewV7() (Uuid, error) { return g.next(7) }
@@ -0,0 +1,200 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+var (
+	_ Generator = (*StdGenerator)(nil)
+	_ Generator = (*SequentialGenerator)(nil)
+)
+
+func TestStdGenerator(t *testing.T) {
+	var g StdGenerator
+	tests := []struct {
+		version int
+		gen     func() (Uuid, error)
+	}{
+		{1, g.NewV1},
+		{4, g.NewV4},
+		{7, g.NewV7},
+	}
+	for _, test := range tests {
+		seen := make(map[UuidKey]bool)
+		for i := 0; i < 1000; i++ {
+			id, err := test.gen()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id.Version() != test.version || id.Variant() != VariantRFC4122 {
+				t.Fatalf("Invalid V%d UUID %v", test.version, id)
+			}
+			if seen[id.Key()] {
+				t.Fatalf("duplicate UUID %v", id)
+			}
+			seen[id.Key()] = true
+		}
+	}
+}
+
+// countingGenerator is a Generator that wraps another and counts calls.
+type countingGenerator struct {
+	Generator
+	n int
+}
+
+func (g *countingGenerator) NewV4() (Uuid, error) {
+	g.n++
+	return g.Generator.NewV4()
+}
+
+func TestGeneratorInjection(t *testing.T) {
+	g := &countingGenerator{Generator: new(StdGenerator)}
+	newID := func(g Generator) Uuid {
+		id, err := g.NewV4()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	newID(g)
+	newID(g)
+	if g.n != 2 {
+		t.Fatalf("want 2 calls got %d", g.n)
+	}
+}
+
+func TestSequentialGenerator(t *testing.T) {
+	var g SequentialGenerator
+	expected := []string{
+		"00000000-0000-4000-8000-000000000001",
+		"00000000-0000-4000-8000-000000000002",
+		"00000000-0000-7000-8000-000000000003",
+		"00000000-0000-1000-8000-000000000004",
+	}
+	gens := []func() (Uuid, error){g.NewV4, g.NewV4, g.NewV7, g.NewV1}
+	for i, gen := range gens {
+		id, err := gen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.String() != expected[i] {
+			t.Fatalf("want %s got %v", expected[i], id)
+		}
+		if _, err := Parse(id.String()); err != nil {
+			t.Fatalf("Parsing of %v failed", id)
+		}
+	}
+}
+
+func TestStdGeneratorRand(t *testing.T) {
+	g := &StdGenerator{Rand: bytes.NewReader(bytes.Repeat([]byte{0xff}, 16))}
+	id, err := g.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := id.String(); s != "ffffffff-ffff-4fff-bfff-ffffffffffff" {
+		t.Fatalf("unexpected UUID %s", s)
+	}
+	if _, err := g.NewV4(); err != io.EOF {
+		t.Fatalf("want %v got %v", io.EOF, err)
+	}
+
+	failure := errors.New("entropy exhausted")
+	g = &StdGenerator{Rand: &failingReader{failure}}
+	for _, gen := range []func() (Uuid, error){g.NewV1, g.NewV4, g.NewV7} {
+		if _, err := gen(); err != failure {
+			t.Fatalf("want %v got %v", failure, err)
+		}
+	}
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+func TestNewErrors(t *testing.T) {
+	defer SetDefaultGenerator(defaultGenerator())
+	for _, gen := range []func() (Uuid, error){NewV1, NewV4, NewV7} {
+		if id, err := gen(); err != nil || len(id) != 16 {
+			t.Fatalf("unexpected result %v %v", id, err)
+		}
+	}
+	failure := errors.New("entropy exhausted")
+	SetDefaultGenerator(&StdGenerator{Rand: &failingReader{failure}})
+	for _, gen := range []func() (Uuid, error){NewV1, NewV4, NewV7} {
+		if _, err := gen(); err != failure {
+			t.Fatalf("want %v got %v", failure, err)
+		}
+	}
+}
+
+func TestSetDefaultGenerator(t *testing.T) {
+	defer SetDefaultGenerator(defaultGenerator())
+	SetDefaultGenerator(new(SequentialGenerator))
+	if s := MakeV4().String(); s != "00000000-0000-4000-8000-000000000001" {
+		t.Fatalf("unexpected UUID %s", s)
+	}
+	SetDefaultGenerator(nil)
+	if id := MakeV4(); id.Version() != 4 || id.isNil() {
+		t.Fatalf("unexpected UUID %v", id)
+	}
+}
+
+func TestStdGeneratorClockNode(t *testing.T) {
+	now := time.Date(2013, 3, 29, 15, 56, 27, 750032000, time.UTC)
+	node := []byte{0x00, 0x1b, 0x63, 0x84, 0x45, 0xe6}
+	g := &StdGenerator{Clock: func() time.Time { return now }, Node: node}
+	id, err := g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm, _ := id.Time(); !tm.Equal(now) {
+		t.Fatalf("want %v got %v", now, tm)
+	}
+	if !bytes.Equal(id[10:], node) {
+		t.Fatalf("want node %x got %x", node, id[10:])
+	}
+	id, _ = g.NewV7()
+	if tm, _ := id.Time(); !tm.Equal(now.Truncate(time.Millisecond)) {
+		t.Fatalf("want %v got %v", now, tm)
+	}
+
+	g = &StdGenerator{Node: []byte{1, 2, 3}}
+	if _, err := g.NewV1(); err != errInvalidNode {
+		t.Fatalf("want %v got %v", errInvalidNode, err)
+	}
+}
+
+func TestSetDefaultGeneratorConcurrent(t *testing.T) {
+	defer SetDefaultGenerator(defaultGenerator())
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			SetDefaultGenerator(new(StdGenerator))
+		}
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+			if id := MakeV4(); id.Version() != 4 {
+				t.Fatalf("unexpected UUID %v", id)
+			}
+		}
+	}
+}
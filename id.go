@@ -0,0 +1,35 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// ID is a UUID identifying a T, so that IDs of different entities are
+// distinct types:
+//
+//	type UserID = uuid.ID[User]
+//	type OrderID = uuid.ID[Order]
+//
+// A UserID cannot be passed where an OrderID is expected. The methods of
+// Uuid, including JSON, text, SQL and protobuf marshaling, are promoted
+// from the embedded field, so an ID encodes exactly like a Uuid.
+type ID[T any] struct {
+	Uuid
+}
+
+// NewID returns a new Version 7 ID, from the default generator.
+func NewID[T any]() (ID[T], error) {
+	id, err := NewV7()
+	return ID[T]{id}, err
+}
+
+// ParseID parses an ID in any form accepted by Parse.
+func ParseID[T any](s string) (ID[T], error) {
+	id, err := Parse(s)
+	return ID[T]{id}, err
+}
+
+// Equal reports whether id and other are the same ID.
+func (id ID[T]) Equal(other ID[T]) bool {
+	return id.Uuid.Equal(other.Uuid)
+}
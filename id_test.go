@@ -0,0 +1,62 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+type testUser struct{}
+
+type userID = ID[testUser]
+
+var (
+	_ json.Marshaler           = userID{}
+	_ json.Unmarshaler         = (*userID)(nil)
+	_ encoding.TextMarshaler   = userID{}
+	_ encoding.TextUnmarshaler = (*userID)(nil)
+	_ sql.Scanner              = (*userID)(nil)
+	_ driver.Valuer            = userID{}
+	_ fmt.Stringer             = userID{}
+)
+
+func TestID(t *testing.T) {
+	id, err := NewID[testUser]()
+	if err != nil || id.Version() != 7 {
+		t.Fatalf("unexpected ID %v %v", id, err)
+	}
+	parsed, err := ParseID[testUser](id.String())
+	if err != nil || !parsed.Equal(id) {
+		t.Fatalf("want %v got %v %v", id, parsed, err)
+	}
+	if _, err := ParseID[testUser]("nope"); err == nil {
+		t.Fatal("invalid ID should fail")
+	}
+
+	type order struct {
+		User userID `json:"user"`
+	}
+	b, err := json.Marshal(order{id})
+	if err != nil || string(b) != `{"user":"`+id.String()+`"}` {
+		t.Fatalf("unexpected JSON %s %v", b, err)
+	}
+	var o order
+	if err := json.Unmarshal(b, &o); err != nil || !o.User.Equal(id) {
+		t.Fatalf("want %v got %v %v", id, o.User, err)
+	}
+
+	var scanned userID
+	if err := scanned.Scan(id.String()); err != nil || !scanned.Equal(id) {
+		t.Fatalf("want %v got %v %v", id, scanned, err)
+	}
+	if s := fmt.Sprint(id); s != id.String() {
+		t.Fatalf("want %v got %s", id, s)
+	}
+}
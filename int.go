@@ -0,0 +1,97 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/bits"
+)
+
+var errOutOfRange = errors.New("uuid: integer out of range")
+
+// Uint64Pair returns uuid as two big-endian halves, so that hi<<64 | lo is
+// the UUID read as a 128-bit unsigned integer.
+func (uuid Uuid) Uint64Pair() (hi, lo uint64) {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	return binary.BigEndian.Uint64(uuid[0:]), binary.BigEndian.Uint64(uuid[8:])
+}
+
+// High64 returns the first 8 bytes of uuid as a big-endian integer, the
+// high half of the UUID read as a 128-bit integer. For time-ordered UUIDs
+// it holds the timestamp, so it orders them the same way as Less.
+func (uuid Uuid) High64() uint64 {
+	hi, _ := uuid.Uint64Pair()
+	return hi
+}
+
+// Low64 returns the last 8 bytes of uuid as a big-endian integer, the low
+// half of the UUID read as a 128-bit integer.
+func (uuid Uuid) Low64() uint64 {
+	_, lo := uuid.Uint64Pair()
+	return lo
+}
+
+// FromUint64Pair returns the UUID with the given big-endian halves. It is the
+// inverse of Uint64Pair.
+func FromUint64Pair(hi, lo uint64) Uuid {
+	uuid := Make()
+	binary.BigEndian.PutUint64(uuid[0:], hi)
+	binary.BigEndian.PutUint64(uuid[8:], lo)
+	return uuid
+}
+
+// BigInt returns uuid read as a 128-bit unsigned big-endian integer, the
+// value Python's UUID.int returns.
+func (uuid Uuid) BigInt() *big.Int {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	return new(big.Int).SetBytes(uuid)
+}
+
+// FromBigInt returns the UUID whose 128-bit unsigned big-endian value is n.
+// It fails if n is negative or does not fit in 128 bits.
+func FromBigInt(n *big.Int) (Uuid, error) {
+	if n.Sign() < 0 || n.BitLen() > 128 {
+		return nil, errOutOfRange
+	}
+	uuid := Make()
+	n.FillBytes(uuid)
+	return uuid, nil
+}
+
+// Add returns uuid plus n, treating the UUID as a 128-bit unsigned integer.
+// The result wraps around on overflow or underflow, which is reported by
+// the second return value. The version and variant bits are not preserved.
+func (uuid Uuid) Add(n int64) (Uuid, bool) {
+	hi, lo := uuid.Uint64Pair()
+	var borrow, carry uint64
+	if n < 0 {
+		lo, borrow = bits.Sub64(lo, uint64(-n), 0)
+		hi, borrow = bits.Sub64(hi, 0, borrow)
+		return FromUint64Pair(hi, lo), borrow != 0
+	}
+	lo, carry = bits.Add64(lo, uint64(n), 0)
+	hi, carry = bits.Add64(hi, 0, carry)
+	return FromUint64Pair(hi, lo), carry != 0
+}
+
+// Increment returns the UUID following uuid in byte order, for use as an
+// exclusive scan bound. The second return value reports whether uuid was
+// the largest possible value, in which case the result is the Nil UUID.
+func (uuid Uuid) Increment() (Uuid, bool) {
+	return uuid.Add(1)
+}
+
+// Decrement returns the UUID preceding uuid in byte order. The second
+// return value reports whether uuid was the Nil UUID, in which case the
+// result has all bits set.
+func (uuid Uuid) Decrement() (Uuid, bool) {
+	return uuid.Add(-1)
+}
@@ -0,0 +1,121 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestUint64Pair(t *testing.T) {
+	id := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	hi, lo := id.Uint64Pair()
+	if hi != 0x0011223344556677 || lo != 0x8899aabbccddeeff {
+		t.Fatalf("unexpected halves %#x %#x", hi, lo)
+	}
+	if id2 := FromUint64Pair(hi, lo); !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+	for i := 0; i < 100; i++ {
+		a, b := MakeV4(), MakeV4()
+		ahi, alo := a.Uint64Pair()
+		bhi, blo := b.Uint64Pair()
+		if a.Less(b) != (ahi < bhi || ahi == bhi && alo < blo) {
+			t.Fatalf("order of %v and %v is not preserved", a, b)
+		}
+	}
+}
+
+func TestHighLow64(t *testing.T) {
+	id := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	if id.High64() != 0x0011223344556677 || id.Low64() != 0x8899aabbccddeeff {
+		t.Fatalf("unexpected halves %#x %#x", id.High64(), id.Low64())
+	}
+	if id.Uint64() != 0x7766554433221100 {
+		t.Fatalf("unexpected Uint64 %#x", id.Uint64())
+	}
+	if Uuid(nil).Uint64() != 0 {
+		t.Fatal("Uint64 of an empty Uuid should be 0")
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	// Python: uuid.UUID("9b78d54c-8cc9-46bc-ae29-efcba10e1abb").int
+	expected, _ := new(big.Int).SetString("206657741187843298016326074089473972923", 10)
+	n := id.BigInt()
+	if n.Cmp(expected) != 0 {
+		t.Fatalf("want %v got %v", expected, n)
+	}
+	id2, err := FromBigInt(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+	id2, err = FromBigInt(big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := id2.String(); s != "00000000-0000-0000-0000-000000000001" {
+		t.Fatalf("unexpected UUID %s", s)
+	}
+	bad := []*big.Int{
+		big.NewInt(-1),
+		new(big.Int).Lsh(big.NewInt(1), 128),
+	}
+	for _, n := range bad {
+		if _, err := FromBigInt(n); err != errOutOfRange {
+			t.Fatalf("%v: want %v got %v", n, errOutOfRange, err)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		a        string
+		n        int64
+		expected string
+		overflow bool
+	}{
+		{"00000000-0000-0000-0000-000000000000", 1, "00000000-0000-0000-0000-000000000001", false},
+		{"00000000-0000-0000-ffff-ffffffffffff", 1, "00000000-0000-0001-0000-000000000000", false},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", 1, "00000000-0000-0000-0000-000000000000", true},
+		{"00000000-0000-0001-0000-000000000000", -1, "00000000-0000-0000-ffff-ffffffffffff", false},
+		{"00000000-0000-0000-0000-000000000000", -1, "ffffffff-ffff-ffff-ffff-ffffffffffff", true},
+		{"00000000-0000-0000-0000-000000000000", math.MinInt64, "ffffffff-ffff-ffff-8000-000000000000", true},
+		{"9b78d54c-8cc9-46bc-ae29-efcba10e1abb", 0, "9b78d54c-8cc9-46bc-ae29-efcba10e1abb", false},
+	}
+	for _, test := range tests {
+		a := parseAny(t, test.a)
+		actual, overflow := a.Add(test.n)
+		if actual.String() != test.expected || overflow != test.overflow {
+			t.Fatalf("%s + %d: want %s, %v got %v, %v", test.a, test.n, test.expected, test.overflow, actual, overflow)
+		}
+	}
+	id := MakeV4()
+	next, _ := id.Increment()
+	prev, _ := next.Decrement()
+	if !id.Less(next) || !prev.Equal(id) {
+		t.Fatalf("Increment and Decrement of %v are not inverse", id)
+	}
+}
+
+// parseAny parses any 128-bit value in canonical form, bypassing the
+// version check of Parse.
+func parseAny(t *testing.T, s string) Uuid {
+	n, ok := new(big.Int).SetString(strings.ReplaceAll(s, "-", ""), 16)
+	if !ok {
+		t.Fatalf("bad test value %s", s)
+	}
+	id, err := FromBigInt(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
@@ -0,0 +1,65 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"sync/atomic"
+)
+
+// Counting is off until EnableMetrics is called, so that generators on many
+// CPUs do not contend on the shared counters unless someone reads them.
+var metrics struct {
+	enabled       atomic.Bool
+	generated     [9]atomic.Uint64 // indexed by version
+	parseFailures atomic.Uint64
+}
+
+// Metrics is a snapshot of the package counters.
+type Metrics struct {
+	// Generated counts the UUIDs made by StdGenerators, by version.
+	Generated map[int]uint64
+
+	// Rekeys counts the times an AES-CTR stream or a NewChaCha8Reader was
+	// keyed from crypto/rand, including the initial keys and those set by
+	// Rekey, ReseedAfterFork, RekeyBytes and RekeyInterval. It is counted
+	// even when metrics are not enabled.
+	Rekeys uint64
+
+	// ParseFailures counts the strings rejected by Parse, ParseKey and
+	// ParseStrict.
+	ParseFailures uint64
+}
+
+// EnableMetrics starts counting generated UUIDs and parse failures.
+func EnableMetrics() {
+	metrics.enabled.Store(true)
+}
+
+// ReadMetrics returns the current counters. Package uuidexpvar publishes
+// them with expvar; they can be wrapped in a collector for other metrics
+// systems such as Prometheus in the same way.
+func ReadMetrics() Metrics {
+	m := Metrics{
+		Generated:     make(map[int]uint64),
+		Rekeys:        streamRekeys.Load(),
+		ParseFailures: metrics.parseFailures.Load(),
+	}
+	for _, version := range []int{1, 4, 7} {
+		m.Generated[version] = metrics.generated[version].Load()
+	}
+	return m
+}
+
+func countGenerated(version int) {
+	if metrics.enabled.Load() {
+		metrics.generated[version].Add(1)
+	}
+}
+
+func countParseFailure() {
+	if metrics.enabled.Load() {
+		metrics.parseFailures.Add(1)
+	}
+}
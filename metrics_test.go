@@ -0,0 +1,38 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	defer metrics.enabled.Store(metrics.enabled.Load())
+	metrics.enabled.Store(false)
+	before := ReadMetrics()
+	MakeV4()
+	Parse("nope")
+	if m := ReadMetrics(); m.Generated[4] != before.Generated[4] || m.ParseFailures != before.ParseFailures {
+		t.Fatalf("metrics counted while disabled: %+v", m)
+	}
+
+	EnableMetrics()
+	MakeV1()
+	MakeV4()
+	MakeV4()
+	MakeV7()
+	Parse("nope")
+	ParseKey("nope")
+	ParseStrict("f47ac10b-58cc-4372-e567-0e02b2c3d479")
+	Rekey()
+	m := ReadMetrics()
+	if m.Generated[1] != before.Generated[1]+1 || m.Generated[4] != before.Generated[4]+2 ||
+		m.Generated[7] != before.Generated[7]+1 {
+		t.Fatalf("unexpected generated counts %v, before %v", m.Generated, before.Generated)
+	}
+	if m.ParseFailures != before.ParseFailures+3 || m.Rekeys <= before.Rekeys {
+		t.Fatalf("unexpected metrics %+v, before %+v", m, before)
+	}
+}
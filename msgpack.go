@@ -0,0 +1,81 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+)
+
+// MessagePack format bytes, see https://github.com/msgpack/msgpack/blob/master/spec.md.
+const (
+	msgpackNil      = 0xc0
+	msgpackBin8     = 0xc4
+	msgpackFixExt16 = 0xd8
+	msgpackStr8     = 0xd9
+)
+
+// MsgpackExtType selects the extension type used by MarshalMsgpack. When it
+// is negative, the default, UUIDs are encoded as 16-byte bin values;
+// otherwise they are encoded as fixext 16 values of this type.
+var MsgpackExtType = -1
+
+var errMsgpackInvalid = errors.New("uuid: invalid MessagePack value")
+
+// MarshalMsgpack implements the msgpack.Marshaler interface of
+// github.com/vmihailenco/msgpack. An empty Uuid is encoded as nil.
+func (uuid Uuid) MarshalMsgpack() ([]byte, error) {
+	if len(uuid) == 0 {
+		return []byte{msgpackNil}, nil
+	}
+	if len(uuid) != 16 {
+		return nil, errInvalidLength
+	}
+	data := make([]byte, 0, 18)
+	if MsgpackExtType >= 0 {
+		data = append(data, msgpackFixExt16, byte(MsgpackExtType))
+	} else {
+		data = append(data, msgpackBin8, 16)
+	}
+	return append(data, uuid...), nil
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface of
+// github.com/vmihailenco/msgpack. It accepts 16-byte bin values, fixext 16
+// values, strings understood by Parse, and nil.
+func (uuid *Uuid) UnmarshalMsgpack(data []byte) error {
+	if len(data) == 1 && data[0] == msgpackNil {
+		*uuid = nil
+		return nil
+	}
+	if len(data) < 2 {
+		return errMsgpackInvalid
+	}
+	switch data[0] {
+	case msgpackBin8:
+		if data[1] != 16 || len(data) != 18 {
+			return errMsgpackInvalid
+		}
+	case msgpackFixExt16:
+		if len(data) != 18 {
+			return errMsgpackInvalid
+		}
+	case msgpackStr8:
+		if int(data[1]) != len(data)-2 {
+			return errMsgpackInvalid
+		}
+		id, err := Parse(string(data[2:]))
+		if err != nil {
+			return err
+		}
+		*uuid = id
+		return nil
+	default:
+		return errMsgpackInvalid
+	}
+	id := Make()
+	copy(id, data[2:])
+	*uuid = id
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMsgpack(t *testing.T) {
+	id := MakeV4()
+	data, err := id.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := append([]byte{0xc4, 16}, id...); !bytes.Equal(data, expected) {
+		t.Fatalf("want %x got %x", expected, data)
+	}
+	var id2 Uuid
+	if err := id2.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+
+	str := append([]byte{0xd9, 36}, id.String()...)
+	id2 = nil
+	if err := id2.UnmarshalMsgpack(str); err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+
+	data, err = Uuid(nil).MarshalMsgpack()
+	if err != nil || !bytes.Equal(data, []byte{0xc0}) {
+		t.Fatalf("empty Uuid should marshal to nil, got %x %v", data, err)
+	}
+	if err := id2.UnmarshalMsgpack(data); err != nil || id2 != nil {
+		t.Fatalf("nil should unmarshal to an empty Uuid, got %v %v", id2, err)
+	}
+
+	bad := [][]byte{
+		nil,
+		{0xc4},
+		append([]byte{0xc4, 15}, id[1:]...),
+		append([]byte{0xd7, 2}, id[8:]...),
+		{0xd9, 36, 'x'},
+	}
+	for _, b := range bad {
+		if err := id2.UnmarshalMsgpack(b); err == nil {
+			t.Fatalf("unmarshaling %x should have failed", b)
+		}
+	}
+}
+
+func TestMsgpackExt(t *testing.T) {
+	defer func(typ int) { MsgpackExtType = typ }(MsgpackExtType)
+	MsgpackExtType = 2
+	id := MakeV4()
+	data, err := id.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := append([]byte{0xd8, 2}, id...); !bytes.Equal(data, expected) {
+		t.Fatalf("want %x got %x", expected, data)
+	}
+	var id2 Uuid
+	if err := id2.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	if !id.Equal(id2) {
+		t.Fatalf("want %v got %v", id, id2)
+	}
+}
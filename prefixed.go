@@ -0,0 +1,84 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+)
+
+// crockfordLower is the Crockford base32 alphabet in lower case, as used
+// by prefixed IDs.
+const crockfordLower = "0123456789abcdefghjkmnpqrstvwxyz"
+
+var errPrefixed = errors.New("uuid: invalid prefixed ID")
+
+// validPrefix reports whether prefix consists of 1 to 63 lower-case ASCII
+// letters.
+func validPrefix(prefix string) bool {
+	if len(prefix) == 0 || len(prefix) > 63 {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if prefix[i] < 'a' || prefix[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Prefixed returns a self-describing external form of uuid: the prefix, an
+// underscore and the 128 bits in 26 characters of lower-case Crockford
+// base32, such as "user_01h455vb4pex5vsknk084sn02q". The encoding is the
+// same as ToULID, so Version 7 UUIDs keep their time order. The prefix
+// must be 1 to 63 lower-case ASCII letters; Prefixed panics otherwise.
+func (uuid Uuid) Prefixed(prefix string) string {
+	if !validPrefix(prefix) {
+		panic("uuid: invalid prefix " + prefix)
+	}
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	b := make([]byte, 0, len(prefix)+27)
+	b = append(append(b, prefix...), '_')
+	return string(uuid.appendBase32(b, crockfordLower))
+}
+
+// ParsePrefixed decodes an ID written by Prefixed, failing unless it has
+// the given prefix. This stops, for example, an order ID from being
+// accepted where a user ID is expected.
+func ParsePrefixed(prefix, s string) (Uuid, error) {
+	if !validPrefix(prefix) || len(s) != len(prefix)+27 ||
+		s[:len(prefix)] != prefix || s[len(prefix)] != '_' {
+		return nil, errPrefixed
+	}
+	uuid, ok := decodeBase32(s[len(prefix)+1:])
+	if !ok {
+		return nil, errPrefixed
+	}
+	return uuid, nil
+}
+
+// NCName returns uuid as "u" followed by 26 characters of lower-case
+// Crockford base32. The result is a valid XML NCName and HTML id: it starts
+// with a letter and contains only letters and digits, unlike the canonical
+// form, which can start with a digit.
+func (uuid Uuid) NCName() string {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	return string(uuid.appendBase32(append(make([]byte, 0, 27), 'u'), crockfordLower))
+}
+
+// ParseNCName decodes the form returned by NCName.
+func ParseNCName(s string) (Uuid, error) {
+	if len(s) != 27 || s[0] != 'u' {
+		return nil, errPrefixed
+	}
+	uuid, ok := decodeBase32(s[1:])
+	if !ok {
+		return nil, errPrefixed
+	}
+	return uuid, nil
+}
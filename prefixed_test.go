@@ -0,0 +1,71 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrefixed(t *testing.T) {
+	id := MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	s := id.Prefixed("user")
+	if want := "user_" + strings.ToLower(id.ToULID()); s != want {
+		t.Fatalf("want %s got %s", want, s)
+	}
+	got, err := ParsePrefixed("user", s)
+	if err != nil || !got.Equal(id) {
+		t.Fatalf("want %v got %v %v", id, got, err)
+	}
+	for _, test := range []struct{ prefix, s string }{
+		{"order", s},
+		{"use", s},
+		{"user", strings.Replace(s, "_", "-", 1)},
+		{"user", s[:len(s)-1]},
+		{"user", s[:len(s)-1] + "u"},
+		{"user", "user_81h455vb4pex5vsknk084sn02q"},
+		{"User", "User" + s[4:]},
+		{"", s[4:]},
+	} {
+		if _, err := ParsePrefixed(test.prefix, test.s); err != errPrefixed {
+			t.Fatalf("%s %s: want %v got %v", test.prefix, test.s, errPrefixed, err)
+		}
+	}
+	a, b := MakeV7(), MakeV7()
+	if a.Prefixed("x") >= b.Prefixed("x") {
+		t.Fatalf("order of %v and %v is not preserved", a, b)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("invalid prefix should panic")
+		}
+	}()
+	id.Prefixed("user_id")
+}
+
+func TestNCName(t *testing.T) {
+	id := MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	s := id.NCName()
+	if want := "u" + strings.ToLower(id.ToULID()); s != want {
+		t.Fatalf("want %s got %s", want, s)
+	}
+	for i := 0; i < 100; i++ {
+		id := MakeV4()
+		s := id.NCName()
+		for _, c := range s {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+				t.Fatalf("%s is not an NCName", s)
+			}
+		}
+		if got, err := ParseNCName(s); err != nil || !got.Equal(id) {
+			t.Fatalf("want %v got %v %v", id, got, err)
+		}
+	}
+	for _, bad := range []string{s[1:], "x" + s[1:], s[:26], s + "0", "u" + id.String()[:26]} {
+		if _, err := ParseNCName(bad); err != errPrefixed {
+			t.Fatalf("%s: want %v got %v", bad, errPrefixed, err)
+		}
+	}
+}
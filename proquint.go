@@ -0,0 +1,82 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The proquint alphabets: each 16-bit word becomes consonant, vowel,
+// consonant, vowel, consonant, carrying 4, 2, 4, 2 and 4 bits.
+const (
+	proquintConsonants = "bdfghjklmnprstvz"
+	proquintVowels     = "aiou"
+)
+
+var errProquint = errors.New("uuid: invalid proquint")
+
+// proquintDec maps proquint letters to their values, or 0xff.
+var proquintDec = func() (dec [256]byte) {
+	for i := range dec {
+		dec[i] = 0xff
+	}
+	for i := 0; i < len(proquintConsonants); i++ {
+		dec[proquintConsonants[i]] = byte(i)
+	}
+	for i := 0; i < len(proquintVowels); i++ {
+		dec[proquintVowels[i]] = byte(i)
+	}
+	return dec
+}()
+
+// Proquint returns uuid as eight pronounceable five-letter words separated
+// by hyphens, such as "lusab-babad-...", following the proquint scheme of
+// Wilkerson. It is meant for IDs that people read aloud, for example to
+// support staff over the phone.
+func (uuid Uuid) Proquint() string {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	b := make([]byte, 0, 47)
+	for i := 0; i < 16; i += 2 {
+		if i > 0 {
+			b = append(b, '-')
+		}
+		w := binary.BigEndian.Uint16(uuid[i:])
+		b = append(b,
+			proquintConsonants[w>>12],
+			proquintVowels[w>>10&3],
+			proquintConsonants[w>>6&15],
+			proquintVowels[w>>4&3],
+			proquintConsonants[w&15])
+	}
+	return string(b)
+}
+
+// ParseProquint decodes the form returned by Proquint.
+func ParseProquint(s string) (Uuid, error) {
+	if len(s) != 47 {
+		return nil, errProquint
+	}
+	uuid := Make()
+	for i := 0; i < 8; i++ {
+		q := s[6*i:]
+		if i < 7 && q[5] != '-' {
+			return nil, errProquint
+		}
+		var w uint16
+		for j, bits := range [5]uint{4, 2, 4, 2, 4} {
+			v := proquintDec[q[j]]
+			isVowel := j%2 == 1
+			if v == 0xff || isVowel != (v < 4 && proquintVowels[v] == q[j]) {
+				return nil, errProquint
+			}
+			w = w<<bits | uint16(v)
+		}
+		binary.BigEndian.PutUint16(uuid[2*i:], w)
+	}
+	return uuid, nil
+}
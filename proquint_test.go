@@ -0,0 +1,37 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProquint(t *testing.T) {
+	// 127.0.0.1 is lusab-babad in the proquint specification.
+	id := Uuid{0x7f, 0, 0, 1, 15: 0}
+	s := id.Proquint()
+	if want := "lusab-babad-babab-babab-babab-babab-babab-babab"; s != want {
+		t.Fatalf("want %s got %s", want, s)
+	}
+	for i := 0; i < 100; i++ {
+		id := MakeV4()
+		got, err := ParseProquint(id.Proquint())
+		if err != nil || !got.Equal(id) {
+			t.Fatalf("want %v got %v %v", id, got, err)
+		}
+	}
+	for _, bad := range []string{
+		s[:46],
+		strings.Replace(s, "-", "_", 1),
+		"aaaaa" + s[5:],
+		"lusob-babad-babab-babab-babab-babab-babab-babac",
+		"lbsab-babad-babab-babab-babab-babab-babab-babab",
+	} {
+		if _, err := ParseProquint(bad); err != errProquint {
+			t.Fatalf("%s: want %v got %v", bad, errProquint, err)
+		}
+	}
+}
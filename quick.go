@@ -0,0 +1,29 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// Generate implements quick.Generator. It returns random RFC 4122 variant
+// UUIDs of versions 1 through 8.
+func (Uuid) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(generate(r))
+}
+
+// Generate implements quick.Generator.
+func (UuidKey) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(generate(r).Key())
+}
+
+func generate(r *rand.Rand) Uuid {
+	uuid := Make()
+	r.Read(uuid)
+	uuid[6] = (uuid[6] & 0xf) | byte(1+r.Intn(8))<<4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return uuid
+}
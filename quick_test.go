@@ -0,0 +1,33 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+var (
+	_ quick.Generator = Uuid(nil)
+	_ quick.Generator = UuidKey{}
+)
+
+func TestQuick(t *testing.T) {
+	versions := make(map[int]bool)
+	f := func(uuid Uuid, key UuidKey) bool {
+		versions[uuid.Version()] = true
+		parsed, err := Parse(uuid.String())
+		return err == nil && parsed.Equal(uuid) &&
+			uuid.Variant() == VariantRFC4122 && key.Uuid().Variant() == VariantRFC4122
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	for v := 1; v <= 8; v++ {
+		if !versions[v] {
+			t.Fatalf("version %d was never generated", v)
+		}
+	}
+}
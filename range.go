@@ -0,0 +1,78 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	errInvalidRange = errors.New("uuid: invalid range: End before Start")
+	errSplitRange   = errors.New("uuid: cannot split range into that many parts")
+)
+
+// Range is the set of UUIDs from Start to End inclusive, in byte order.
+// Inclusive bounds allow the whole keyspace to be expressed as the range
+// from the Nil UUID to the UUID with all bits set.
+type Range struct {
+	Start, End Uuid
+}
+
+// Validate reports whether both bounds are 16 bytes long and Start does not
+// sort after End.
+func (r Range) Validate() error {
+	if len(r.Start) != 16 || len(r.End) != 16 {
+		return errInvalidLength
+	}
+	if r.End.Less(r.Start) {
+		return errInvalidRange
+	}
+	return nil
+}
+
+// Contains reports whether uuid lies within r.
+func (r Range) Contains(uuid Uuid) bool {
+	return !uuid.Less(r.Start) && !r.End.Less(uuid)
+}
+
+// Overlaps reports whether r and other have at least one UUID in common.
+func (r Range) Overlaps(other Range) bool {
+	return !other.End.Less(r.Start) && !r.End.Less(other.Start)
+}
+
+func (r Range) String() string {
+	return "[" + r.Start.String() + ", " + r.End.String() + "]"
+}
+
+// Split divides r into n contiguous sub-ranges of as equal size as
+// possible, in order. The first sub-ranges are one UUID larger when the
+// size of r is not a multiple of n. It fails if r is invalid or holds fewer
+// than n UUIDs.
+func (r Range) Split(n int) ([]Range, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	one := big.NewInt(1)
+	start := r.Start.BigInt()
+	size := new(big.Int).Sub(r.End.BigInt(), start)
+	size.Add(size, one)
+	if n < 1 || size.Cmp(big.NewInt(int64(n))) < 0 {
+		return nil, errSplitRange
+	}
+	step, rem := new(big.Int).QuoRem(size, big.NewInt(int64(n)), new(big.Int))
+	parts := make([]Range, n)
+	for i := range parts {
+		next := new(big.Int).Add(start, step)
+		if rem.Sign() > 0 {
+			next.Add(next, one)
+			rem.Sub(rem, one)
+		}
+		parts[i].Start, _ = FromBigInt(start)
+		parts[i].End, _ = FromBigInt(new(big.Int).Sub(next, one))
+		start = next
+	}
+	return parts, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	r := Range{
+		Start: MustParse("40000000-0000-4000-8000-000000000000"),
+		End:   MustParse("7fffffff-ffff-4fff-bfff-ffffffffffff"),
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	contains := []struct {
+		uuid     string
+		expected bool
+	}{
+		{"40000000-0000-4000-8000-000000000000", true},
+		{"7fffffff-ffff-4fff-bfff-ffffffffffff", true},
+		{"5b78d54c-8cc9-46bc-ae29-efcba10e1abb", true},
+		{"3fffffff-ffff-4fff-bfff-ffffffffffff", false},
+		{"9b78d54c-8cc9-46bc-ae29-efcba10e1abb", false},
+	}
+	for _, test := range contains {
+		if r.Contains(MustParse(test.uuid)) != test.expected {
+			t.Fatalf("%v.Contains(%s) should be %v", r, test.uuid, test.expected)
+		}
+	}
+	overlaps := []struct {
+		other    Range
+		expected bool
+	}{
+		{Range{MustParse("00000000-0000-4000-8000-000000000000"), MustParse("40000000-0000-4000-8000-000000000000")}, true},
+		{Range{MustParse("7fffffff-ffff-4fff-bfff-ffffffffffff"), MustParse("9fffffff-ffff-4fff-bfff-ffffffffffff")}, true},
+		{Range{MustParse("50000000-0000-4000-8000-000000000000"), MustParse("60000000-0000-4000-8000-000000000000")}, true},
+		{Range{MustParse("00000000-0000-4000-8000-000000000000"), MustParse("3fffffff-ffff-4fff-bfff-ffffffffffff")}, false},
+		{Range{MustParse("80000000-0000-4000-8000-000000000000"), MustParse("9fffffff-ffff-4fff-bfff-ffffffffffff")}, false},
+	}
+	for _, test := range overlaps {
+		if r.Overlaps(test.other) != test.expected || test.other.Overlaps(r) != test.expected {
+			t.Fatalf("%v.Overlaps(%v) should be %v", r, test.other, test.expected)
+		}
+	}
+	if err := (Range{r.End, r.Start}).Validate(); err != errInvalidRange {
+		t.Fatalf("want %v got %v", errInvalidRange, err)
+	}
+	if err := (Range{r.Start, nil}).Validate(); err != errInvalidLength {
+		t.Fatalf("want %v got %v", errInvalidLength, err)
+	}
+}
+
+func TestRangeSplit(t *testing.T) {
+	all := Range{
+		Start: MustParse("00000000-0000-0000-0000-000000000000"),
+		End:   parseAny(t, "ffffffff-ffff-ffff-ffff-ffffffffffff"),
+	}
+	parts, err := all.Split(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"00000000-0000-0000-0000-000000000000", "3fffffff-ffff-ffff-ffff-ffffffffffff",
+		"40000000-0000-0000-0000-000000000000", "7fffffff-ffff-ffff-ffff-ffffffffffff",
+		"80000000-0000-0000-0000-000000000000", "bfffffff-ffff-ffff-ffff-ffffffffffff",
+		"c0000000-0000-0000-0000-000000000000", "ffffffff-ffff-ffff-ffff-ffffffffffff",
+	}
+	for i, part := range parts {
+		if part.Start.String() != expected[2*i] || part.End.String() != expected[2*i+1] {
+			t.Fatalf("part %d: want [%s, %s] got %v", i, expected[2*i], expected[2*i+1], part)
+		}
+	}
+
+	small := Range{Start: parseAny(t, "00000000-0000-0000-0000-000000000005"), End: parseAny(t, "00000000-0000-0000-0000-00000000000f")}
+	for n := 1; n <= 11; n++ {
+		parts, err := small.Split(n)
+		if err != nil {
+			t.Fatalf("Split(%d): %v", n, err)
+		}
+		if len(parts) != n || !parts[0].Start.Equal(small.Start) || !parts[n-1].End.Equal(small.End) {
+			t.Fatalf("Split(%d) does not cover %v: %v", n, small, parts)
+		}
+		for i := 1; i < n; i++ {
+			next, _ := parts[i-1].End.Increment()
+			if !next.Equal(parts[i].Start) {
+				t.Fatalf("Split(%d): %v and %v are not contiguous", n, parts[i-1], parts[i])
+			}
+		}
+	}
+	for _, n := range []int{0, -1, 12} {
+		if _, err := small.Split(n); err != errSplitRange {
+			t.Fatalf("Split(%d): want %v got %v", n, errSplitRange, err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// Reader is an io.Reader producing an endless stream of new UUIDs, either
+// as consecutive 16-byte values or as canonical strings, one per line.
+// UUIDs split across Read calls continue where the previous call stopped.
+type Reader struct {
+	newUuid func() (Uuid, error)
+	text    bool
+	pending []byte
+	buf     [37]byte
+}
+
+// NewReader returns a Reader streaming the 16 raw bytes of each UUID made
+// by newUuid, for example uuid.NewReader(g.NewV4) for a Generator g.
+func NewReader(newUuid func() (Uuid, error)) *Reader {
+	return &Reader{newUuid: newUuid}
+}
+
+// NewTextReader returns a Reader streaming the canonical form of each UUID
+// made by newUuid, each followed by a newline.
+func NewTextReader(newUuid func() (Uuid, error)) *Reader {
+	return &Reader{newUuid: newUuid, text: true}
+}
+
+// Read fills p with UUIDs. It only returns an error if newUuid fails or
+// returns a Uuid that is not 16 bytes long.
+func (r *Reader) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		if len(r.pending) == 0 {
+			id, err := r.newUuid()
+			if err != nil {
+				return n, err
+			}
+			if len(id) != 16 {
+				return n, errInvalidLength
+			}
+			if r.text {
+				r.pending = append(id.appendCanonical(r.buf[:0]), '\n')
+			} else {
+				r.pending = append(r.buf[:0], id...)
+			}
+		}
+		c := copy(p[n:], r.pending)
+		r.pending = r.pending[c:]
+		n += c
+	}
+	return n, nil
+}
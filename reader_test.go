@@ -0,0 +1,86 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	var g SequentialGenerator
+	r := NewReader(g.NewV4)
+	// Read in odd-sized pieces to split UUIDs across calls.
+	b := make([]byte, 0, 48)
+	for len(b) < 48 {
+		n, err := r.Read(b[len(b):min(len(b)+7, cap(b))])
+		if err != nil {
+			t.Fatal(err)
+		}
+		b = b[:len(b)+n]
+	}
+	expected := []string{
+		"00000000-0000-4000-8000-000000000001",
+		"00000000-0000-4000-8000-000000000002",
+		"00000000-0000-4000-8000-000000000003",
+	}
+	for i := range expected {
+		if id := Uuid(b[16*i : 16*i+16]); id.String() != expected[i] {
+			t.Fatalf("want %s got %v", expected[i], id)
+		}
+	}
+}
+
+func TestTextReader(t *testing.T) {
+	var g SequentialGenerator
+	s := bufio.NewScanner(io.LimitReader(NewTextReader(g.NewV4), 3*37))
+	var lines []string
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	expected := []string{
+		"00000000-0000-4000-8000-000000000001",
+		"00000000-0000-4000-8000-000000000002",
+		"00000000-0000-4000-8000-000000000003",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("want %v got %v", expected, lines)
+	}
+	for i := range lines {
+		if lines[i] != expected[i] {
+			t.Fatalf("want %v got %v", expected, lines)
+		}
+	}
+}
+
+func TestReaderError(t *testing.T) {
+	failure := errors.New("failed")
+	calls := 0
+	r := NewReader(func() (Uuid, error) {
+		if calls++; calls > 1 {
+			return nil, failure
+		}
+		return MakeV4(), nil
+	})
+	n, err := r.Read(make([]byte, 40))
+	if n != 16 || err != failure {
+		t.Fatalf("want 16, %v got %d, %v", failure, n, err)
+	}
+}
+
+func TestReaderInvalidLength(t *testing.T) {
+	for _, id := range []Uuid{nil, {1, 2, 3}} {
+		for _, r := range []*Reader{
+			NewReader(func() (Uuid, error) { return id, nil }),
+			NewTextReader(func() (Uuid, error) { return id, nil }),
+		} {
+			if n, err := r.Read(make([]byte, 40)); n != 0 || err != errInvalidLength {
+				t.Fatalf("want 0, %v got %d, %v", errInvalidLength, n, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"sync"
+)
+
+// Set is a set of UUIDs. The zero value is an empty set that can be
+// read but not added to; use NewSet or make(Set).
+type Set map[UuidKey]struct{}
+
+// NewSet returns a set containing ids.
+func NewSet(ids ...Uuid) Set {
+	s := make(Set, len(ids))
+	for _, id := range ids {
+		s.Add(id)
+	}
+	return s
+}
+
+// Add adds uuid to the set.
+func (s Set) Add(uuid Uuid) {
+	s[uuid.Key()] = struct{}{}
+}
+
+// Remove removes uuid from the set.
+func (s Set) Remove(uuid Uuid) {
+	delete(s, uuid.Key())
+}
+
+// Has reports whether uuid is in the set.
+func (s Set) Has(uuid Uuid) bool {
+	_, ok := s[uuid.Key()]
+	return ok
+}
+
+// Len returns the number of UUIDs in the set.
+func (s Set) Len() int {
+	return len(s)
+}
+
+// ToSlice returns the UUIDs in the set, in no particular order.
+func (s Set) ToSlice() Uuids {
+	ids := make(Uuids, 0, len(s))
+	for key := range s {
+		ids = append(ids, key.Uuid())
+	}
+	return ids
+}
+
+const syncSetShards = 32
+
+// SyncSet is a set of UUIDs that is safe for concurrent use. It is split
+// into shards with their own locks, so goroutines adding different UUIDs
+// rarely contend. The zero value is an empty set ready to use.
+type SyncSet struct {
+	shards [syncSetShards]syncSetShard
+}
+
+type syncSetShard struct {
+	sync.RWMutex
+	m map[UuidKey]struct{}
+}
+
+// shard picks the shard for key from a byte of the timestamp and a byte of
+// the node or random bits, so that both V1 and V7 UUIDs spread evenly.
+func (s *SyncSet) shard(key UuidKey) *syncSetShard {
+	return &s.shards[(key[0]^key[15])%syncSetShards]
+}
+
+// Add adds uuid to the set. It reports whether uuid was not already
+// present, so that a caller can use it to deduplicate requests.
+func (s *SyncSet) Add(uuid Uuid) bool {
+	key := uuid.Key()
+	sh := s.shard(key)
+	sh.Lock()
+	defer sh.Unlock()
+	if _, ok := sh.m[key]; ok {
+		return false
+	}
+	if sh.m == nil {
+		sh.m = make(map[UuidKey]struct{})
+	}
+	sh.m[key] = struct{}{}
+	return true
+}
+
+// Remove removes uuid from the set.
+func (s *SyncSet) Remove(uuid Uuid) {
+	key := uuid.Key()
+	sh := s.shard(key)
+	sh.Lock()
+	delete(sh.m, key)
+	sh.Unlock()
+}
+
+// Has reports whether uuid is in the set.
+func (s *SyncSet) Has(uuid Uuid) bool {
+	key := uuid.Key()
+	sh := s.shard(key)
+	sh.RLock()
+	_, ok := sh.m[key]
+	sh.RUnlock()
+	return ok
+}
+
+// Len returns the number of UUIDs in the set. If other goroutines modify
+// the set concurrently, the result may not reflect any single moment.
+func (s *SyncSet) Len() int {
+	n := 0
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.RLock()
+		n += len(sh.m)
+		sh.RUnlock()
+	}
+	return n
+}
+
+// ToSlice returns the UUIDs in the set, in no particular order. Like Len,
+// it locks one shard at a time.
+func (s *SyncSet) ToSlice() Uuids {
+	var ids Uuids
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.RLock()
+		for key := range sh.m {
+			ids = append(ids, key.Uuid())
+		}
+		sh.RUnlock()
+	}
+	return ids
+}
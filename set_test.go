@@ -0,0 +1,81 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	a, b, c := MakeV4(), MakeV4(), MakeV4()
+	s := NewSet(a, b, a)
+	if s.Len() != 2 || !s.Has(a) || !s.Has(b) || s.Has(c) {
+		t.Fatalf("unexpected set %v", s)
+	}
+	s.Add(c)
+	s.Remove(a)
+	s.Remove(a)
+	if s.Len() != 2 || s.Has(a) || !s.Has(c) {
+		t.Fatalf("unexpected set %v", s)
+	}
+	ids := s.ToSlice()
+	sort.Sort(ids)
+	want := Uuids{b, c}
+	sort.Sort(want)
+	if len(ids) != 2 || !ids[0].Equal(want[0]) || !ids[1].Equal(want[1]) {
+		t.Fatalf("want %v got %v", want, ids)
+	}
+	var empty Set
+	if empty.Has(a) || empty.Len() != 0 || len(empty.ToSlice()) != 0 {
+		t.Fatal("zero Set should be empty")
+	}
+}
+
+func TestSyncSet(t *testing.T) {
+	const goroutines, count = 8, 500
+	ids := make(Uuids, count)
+	for i := range ids {
+		ids[i] = MakeV7()
+	}
+	var s SyncSet
+	added := make(chan int, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := 0
+			for _, id := range ids {
+				if s.Add(id) {
+					n++
+				}
+			}
+			added <- n
+		}()
+	}
+	wg.Wait()
+	close(added)
+	total := 0
+	for n := range added {
+		total += n
+	}
+	if total != count || s.Len() != count {
+		t.Fatalf("want %d got %d added, %d in set", count, total, s.Len())
+	}
+	for _, id := range ids {
+		if !s.Has(id) {
+			t.Fatalf("%v missing", id)
+		}
+	}
+	if got := s.ToSlice(); len(got) != count {
+		t.Fatalf("want %d got %d", count, len(got))
+	}
+	s.Remove(ids[0])
+	if s.Has(ids[0]) || s.Len() != count-1 {
+		t.Fatalf("%v not removed", ids[0])
+	}
+}
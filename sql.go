@@ -0,0 +1,126 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// DriverValueFormat selects the representation returned by Value. Use
+// FormatBinary for BINARY(16) columns.
+var DriverValueFormat = FormatCanonical
+
+// Scan implements sql.Scanner. It accepts 16-byte blobs and any string form
+// understood by Parse. A NULL value scans to an empty Uuid.
+func (uuid *Uuid) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*uuid = nil
+		return nil
+	case []byte:
+		if len(src) == 16 {
+			id := Make()
+			copy(id, src)
+			*uuid = id
+			return nil
+		}
+		return uuid.scanString(string(src))
+	case string:
+		return uuid.scanString(src)
+	}
+	return fmt.Errorf("uuid: cannot scan %T into Uuid", src)
+}
+
+func (uuid *Uuid) scanString(s string) error {
+	id, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*uuid = id
+	return nil
+}
+
+// Value implements driver.Valuer. An empty Uuid is stored as NULL.
+func (uuid Uuid) Value() (driver.Value, error) {
+	if len(uuid) == 0 {
+		return nil, nil
+	}
+	if len(uuid) != 16 {
+		return nil, errInvalidLength
+	}
+	if DriverValueFormat == FormatBinary {
+		return append([]byte(nil), uuid...), nil
+	}
+	return string(uuid.AppendFormat(make([]byte, 0, 45), DriverValueFormat)), nil
+}
+
+// Scan implements sql.Scanner like Uuid.Scan. A NULL value scans to the
+// zero key.
+func (key *UuidKey) Scan(src interface{}) error {
+	var id Uuid
+	if err := id.Scan(src); err != nil {
+		return err
+	}
+	return key.set(id)
+}
+
+// Value implements driver.Valuer like Uuid.Value. The zero key is stored as
+// the Nil UUID, not NULL.
+func (key UuidKey) Value() (driver.Value, error) {
+	return key.Uuid().Value()
+}
+
+// NullUuid represents a Uuid that may be null. It implements sql.Scanner
+// and driver.Valuer like sql.NullString, and marshals to JSON null when not
+// Valid.
+type NullUuid struct {
+	Uuid  Uuid
+	Valid bool // Valid is true if Uuid is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (nu *NullUuid) Scan(src interface{}) error {
+	if src == nil {
+		nu.Uuid, nu.Valid = nil, false
+		return nil
+	}
+	if err := nu.Uuid.Scan(src); err != nil {
+		nu.Valid = false
+		return err
+	}
+	nu.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (nu NullUuid) Value() (driver.Value, error) {
+	if !nu.Valid {
+		return nil, nil
+	}
+	return nu.Uuid.Value()
+}
+
+func (nu NullUuid) MarshalJSON() ([]byte, error) {
+	if !nu.Valid {
+		return []byte("null"), nil
+	}
+	return nu.Uuid.MarshalJSON()
+}
+
+// UnmarshalJSON decodes null, and the empty forms that Uuid.UnmarshalJSON
+// accepts, as an invalid NullUuid.
+func (nu *NullUuid) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		nu.Uuid, nu.Valid = nil, false
+		return nil
+	}
+	if err := nu.Uuid.UnmarshalJSON(data); err != nil {
+		nu.Valid = false
+		return err
+	}
+	nu.Valid = len(nu.Uuid) != 0
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*Uuid)(nil)
+	_ driver.Valuer = Uuid(nil)
+	_ sql.Scanner   = (*NullUuid)(nil)
+	_ driver.Valuer = NullUuid{}
+	_ sql.Scanner   = (*UuidKey)(nil)
+	_ driver.Valuer = UuidKey{}
+)
+
+func TestScan(t *testing.T) {
+	id := MakeV4()
+	srcs := []interface{}{
+		[]byte(id),
+		id.String(),
+		[]byte(id.String()),
+		id.Braced(),
+		id.UpperString(),
+	}
+	for _, src := range srcs {
+		var id2 Uuid
+		if err := id2.Scan(src); err != nil {
+			t.Fatalf("Scan(%v): %v", src, err)
+		}
+		if !id.Equal(id2) {
+			t.Fatalf("Scan(%v): want %v got %v", src, id, id2)
+		}
+	}
+	var id2 Uuid
+	b := append([]byte(nil), id...)
+	if err := id2.Scan(b); err != nil {
+		t.Fatal(err)
+	}
+	b[0]++
+	if id2[0] == b[0] {
+		t.Fatal("Scan should copy its input")
+	}
+	if err := id2.Scan(nil); err != nil || id2 != nil {
+		t.Fatalf("NULL should scan to an empty Uuid, got %v, %v", id2, err)
+	}
+	if err := id2.Scan(int64(1)); err == nil {
+		t.Fatal("Scan of an int64 should have failed")
+	}
+	if err := id2.Scan("9b78d54c"); err != errParseFailed {
+		t.Fatalf("want %v got %v", errParseFailed, err)
+	}
+}
+
+func TestValue(t *testing.T) {
+	id := MakeV4()
+	v, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != id.String() {
+		t.Fatalf("want %v got %v", id.String(), v)
+	}
+	v, err = Uuid(nil).Value()
+	if err != nil || v != nil {
+		t.Fatalf("want nil got %v, %v", v, err)
+	}
+
+	defer func(format Format) { DriverValueFormat = format }(DriverValueFormat)
+	DriverValueFormat = FormatBinary
+	v, err = id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := v.([]byte); !ok || !bytes.Equal(b, id) {
+		t.Fatalf("want %x got %v", []byte(id), v)
+	}
+	DriverValueFormat = FormatBraced
+	if v, _ = id.Value(); v != id.Braced() {
+		t.Fatalf("want %v got %v", id.Braced(), v)
+	}
+}
+
+func TestKeyScanValue(t *testing.T) {
+	id := MakeV4()
+	var key UuidKey
+	for _, src := range []interface{}{id.String(), []byte(id)} {
+		if err := key.Scan(src); err != nil || key != id.Key() {
+			t.Fatalf("want %v got %v %v", id, key, err)
+		}
+	}
+	if err := key.Scan(nil); err != nil || key != (UuidKey{}) {
+		t.Fatalf("want zero key got %v %v", key, err)
+	}
+	if err := key.Scan(42); err == nil {
+		t.Fatal("scanning an int should fail")
+	}
+	v, err := id.Key().Value()
+	if err != nil || v != id.String() {
+		t.Fatalf("want %v got %v %v", id, v, err)
+	}
+}
+
+func TestNullUuid(t *testing.T) {
+	id := MakeV4()
+	var nu NullUuid
+	if err := nu.Scan(id.String()); err != nil {
+		t.Fatal(err)
+	}
+	if !nu.Valid || !nu.Uuid.Equal(id) {
+		t.Fatalf("want %v got %v", id, nu)
+	}
+	if v, err := nu.Value(); err != nil || v != id.String() {
+		t.Fatalf("want %v got %v, %v", id.String(), v, err)
+	}
+	if err := nu.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if nu.Valid {
+		t.Fatal("NULL should scan to an invalid NullUuid")
+	}
+	if v, err := nu.Value(); err != nil || v != nil {
+		t.Fatalf("want nil got %v, %v", v, err)
+	}
+	if err := nu.Scan("bogus"); err == nil || nu.Valid {
+		t.Fatal("Scan of a malformed value should fail and leave NullUuid invalid")
+	}
+}
+
+type myNullStruct struct {
+	Id NullUuid
+}
+
+func TestNullUuidJSON(t *testing.T) {
+	data, err := json.Marshal(&myNullStruct{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"Id":null}` {
+		t.Fatalf("unexpected encoding %s", data)
+	}
+	m := &myNullStruct{Id: NullUuid{Uuid: MakeV4(), Valid: true}}
+	data, err = json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &myNullStruct{}
+	if err := json.Unmarshal(data, u); err != nil {
+		t.Fatal(err)
+	}
+	if !u.Id.Valid || !u.Id.Uuid.Equal(m.Id.Uuid) {
+		t.Fatalf("want %v got %v", m, u)
+	}
+	if err := json.Unmarshal([]byte(`{"Id":null}`), u); err != nil {
+		t.Fatal(err)
+	}
+	if u.Id.Valid {
+		t.Fatal("null should decode to an invalid NullUuid")
+	}
+	for _, s := range []string{`""`, `"<empty uuid>"`} {
+		u := &myNullStruct{Id: NullUuid{Uuid: MakeV4(), Valid: true}}
+		if err := json.Unmarshal([]byte(`{"Id":`+s+`}`), u); err != nil {
+			t.Fatal(err)
+		}
+		if u.Id.Valid || u.Id.Uuid != nil {
+			t.Fatalf("%s should decode to an invalid NullUuid, got %v", s, u.Id)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RekeyBytes and RekeyInterval limit how much keystream each AES-CTR stream
+// and NewChaCha8Reader produces, and for how long, before it is rekeyed from
+// crypto/rand. A zero value disables the corresponding limit.
+var (
+	RekeyBytes    int64 = 1 << 30
+	RekeyInterval       = time.Hour
+)
+
+// ctrStream is an AES-CTR keystream with its own key and IV.
+type ctrStream struct {
+	stream     cipher.Stream
+	bytes      int64
+	keyed      time.Time
+	generation uint64
+
+	// buf holds pre-generated keystream when the random pool is enabled.
+	// Bytes before pos have been handed out and cleared.
+	buf []byte
+	pos int
+}
+
+const randPoolSize = 16 * 256
+
+var randPool atomic.Bool
+
+// EnableRandPool makes the package AES-CTR streams generate keystream in
+// blocks of 4 KiB and hand out UUID-sized pieces of it, which speeds up
+// bulk generation considerably. The caveat is that random bytes for future
+// UUIDs are kept in memory, where a memory disclosure bug could reveal
+// them. Bytes are cleared from the buffer as they are used.
+func EnableRandPool() {
+	randPool.Store(true)
+}
+
+// DisableRandPool turns off the random pool enabled by EnableRandPool.
+func DisableRandPool() {
+	randPool.Store(false)
+}
+
+// The package keeps a pool of independently keyed streams rather than a
+// single locked one. sync.Pool caches its items per P, so goroutines on
+// different CPUs generate UUIDs without contending. Rekey bumps
+// streamGeneration, and streams from an older generation rekey themselves
+// before their next use.
+var streamPool sync.Pool
+var streamGeneration atomic.Uint64
+var streamRekeys atomic.Uint64
+
+// If the initial keys cannot be read, the streams are keyed on first use
+// instead, and the error is returned from NewV4 and friends.
+func init() {
+	Rekey()
+}
+
+// InitState rekeys the package AES-CTR streams. It panics if crypto/rand
+// fails; use Rekey to handle the error instead.
+func InitState() {
+	if err := Rekey(); err != nil {
+		panic(err)
+	}
+}
+
+// Rekey replaces the keys and IVs of the package AES-CTR streams with fresh
+// values from crypto/rand. Streams in use by other goroutines, and readers
+// made by NewChaCha8Reader, are rekeyed before they produce more output.
+func Rekey() error {
+	streamGeneration.Add(1)
+	s := new(ctrStream)
+	if err := s.rekey(); err != nil {
+		return err
+	}
+	streamPool.Put(s)
+	return nil
+}
+
+// reseedGeneration is bumped by ReseedAfterFork. Generators compare it with
+// the value they last saw to discard their V1 clock sequence and node.
+var reseedGeneration atomic.Uint64
+
+// ReseedAfterFork discards all random state held by the package: it rekeys
+// the AES-CTR streams and makes every StdGenerator choose a new V1 clock
+// sequence and node ID. Call it in a process that has been cloned with its
+// memory intact, such as a resumed VM snapshot or a cloned VM, before it
+// makes any UUIDs; otherwise the clones continue the same keystreams and
+// make identical UUIDs.
+//
+// Go programs cannot fork without exec, and cloned VMs keep the same
+// process ID, so the package cannot reliably detect a clone itself. Linux
+// reseeds crypto/rand on VM generation changes, so the new keys are
+// independent even if the hook is called from several clones.
+//
+// ReseedAfterFork cannot reach state outside the package. A StdGenerator
+// with its own Rand keeps reading from that reader, which the application
+// must reseed or replace itself, unless it is crypto/rand.Reader or was
+// made by NewChaCha8Reader. The V1 clock sequence and node of such a
+// generator are still chosen again, from its Rand.
+func ReseedAfterFork() error {
+	reseedGeneration.Add(1)
+	return Rekey()
+}
+
+func (s *ctrStream) rekey() error {
+	// select AES-256
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return err
+	}
+	s.stream = cipher.NewCTR(block, iv)
+	clear(s.buf)
+	s.pos = len(s.buf)
+	s.bytes = 0
+	s.keyed = time.Now()
+	s.generation = streamGeneration.Load()
+	streamRekeys.Add(1)
+	return nil
+}
+
+// stale reports whether s must be rekeyed before further use.
+func (s *ctrStream) stale() bool {
+	return s.stream == nil || s.generation != streamGeneration.Load() ||
+		RekeyBytes > 0 && s.bytes >= RekeyBytes ||
+		RekeyInterval > 0 && time.Since(s.keyed) >= RekeyInterval
+}
+
+// readStream fills b with the next bytes of a package AES-CTR stream.
+func readStream(b []byte) error {
+	clear(b)
+	s, _ := streamPool.Get().(*ctrStream)
+	if s == nil {
+		s = new(ctrStream)
+	}
+	if err := s.read(b); err != nil {
+		return err
+	}
+	streamPool.Put(s)
+	return nil
+}
+
+// read XORs b with the next bytes of s, rekeying s first if it is stale.
+func (s *ctrStream) read(b []byte) error {
+	if s.stale() {
+		if err := s.rekey(); err != nil {
+			return err
+		}
+	}
+	if randPool.Load() {
+		s.readPool(b)
+	} else {
+		s.stream.XORKeyStream(b, b)
+		s.bytes += int64(len(b))
+	}
+	return nil
+}
+
+// readPool fills b from the pre-generated keystream of s, refilling it as
+// needed.
+func (s *ctrStream) readPool(b []byte) {
+	for len(b) > 0 {
+		if s.pos == len(s.buf) {
+			if s.buf == nil {
+				s.buf = make([]byte, randPoolSize)
+			}
+			s.stream.XORKeyStream(s.buf, s.buf)
+			s.bytes += int64(len(s.buf))
+			s.pos = 0
+		}
+		n := copy(b, s.buf[s.pos:])
+		clear(s.buf[s.pos : s.pos+n])
+		s.pos += n
+		b = b[n:]
+	}
+}
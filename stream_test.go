@@ -0,0 +1,154 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRekey(t *testing.T) {
+	MakeV4()
+	n := streamRekeys.Load()
+	if err := Rekey(); err != nil {
+		t.Fatal(err)
+	}
+	MakeV4()
+	if streamRekeys.Load() == n {
+		t.Fatal("Rekey should replace the stream")
+	}
+}
+
+func TestReseedAfterFork(t *testing.T) {
+	var g StdGenerator
+	a, _ := g.NewV1()
+	n := streamRekeys.Load()
+	if err := ReseedAfterFork(); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := g.NewV1()
+	if streamRekeys.Load() == n {
+		t.Fatal("ReseedAfterFork should rekey the stream")
+	}
+	if a[8:].Equal(b[8:]) {
+		t.Fatalf("clock sequence and node not reset: %v %v", a, b)
+	}
+}
+
+func TestRekeyBytes(t *testing.T) {
+	defer func(n int64) { RekeyBytes = n }(RekeyBytes)
+	// Use a stream of its own: sync.Pool may drop the package streams.
+	s := new(ctrStream)
+	b := make([]byte, 16)
+	RekeyBytes = 64
+	s.read(b)
+	stream := s.stream
+	for i := 0; i < 5; i++ {
+		s.read(b)
+	}
+	if s.stream == stream {
+		t.Fatal("stream not rekeyed after RekeyBytes")
+	}
+	RekeyBytes = 0
+	stream = s.stream
+	for i := 0; i < 100; i++ {
+		s.read(b)
+	}
+	if s.stream != stream {
+		t.Fatal("stream rekeyed without a limit")
+	}
+}
+
+func TestRekeyInterval(t *testing.T) {
+	defer func(d time.Duration) { RekeyInterval = d }(RekeyInterval)
+	RekeyInterval = time.Nanosecond
+	MakeV4()
+	n := streamRekeys.Load()
+	time.Sleep(time.Millisecond)
+	MakeV4()
+	if streamRekeys.Load() == n {
+		t.Fatal("stream not rekeyed after RekeyInterval")
+	}
+}
+
+func TestConcurrentV4(t *testing.T) {
+	const goroutines, count = 8, 1000
+	ids := make([]Uuids, goroutines)
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				ids[i] = append(ids[i], MakeV4())
+			}
+		}(i)
+	}
+	wg.Wait()
+	seen := make(map[UuidKey]bool)
+	for _, list := range ids {
+		for _, id := range list {
+			if seen[id.Key()] {
+				t.Fatalf("duplicate UUID %v", id)
+			}
+			seen[id.Key()] = true
+		}
+	}
+}
+
+func BenchmarkMakeV4Parallel(b *testing.B) {
+	b.SetBytes(16)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = MakeV4()
+		}
+	})
+}
+
+func TestRandPool(t *testing.T) {
+	EnableRandPool()
+	defer DisableRandPool()
+	seen := make(map[UuidKey]bool)
+	for i := 0; i < 3*randPoolSize/16; i++ {
+		id := MakeV4()
+		if id.Version() != 4 || seen[id.Key()] {
+			t.Fatalf("unexpected UUID %v", id)
+		}
+		seen[id.Key()] = true
+	}
+	// Rekeying discards the pre-generated keystream.
+	n := streamRekeys.Load()
+	Rekey()
+	MakeV4()
+	if streamRekeys.Load() == n {
+		t.Fatal("Rekey should replace the stream")
+	}
+	s := new(ctrStream)
+	s.rekey()
+	b := make([]byte, 10)
+	s.readPool(b)
+	if s.pos != 10 || len(s.buf) != randPoolSize {
+		t.Fatalf("unexpected pool state %d/%d", s.pos, len(s.buf))
+	}
+	for _, c := range s.buf[:s.pos] {
+		if c != 0 {
+			t.Fatal("used pool bytes should be cleared")
+		}
+	}
+	s.rekey()
+	if s.pos != len(s.buf) {
+		t.Fatal("rekey should discard the pool")
+	}
+}
+
+func BenchmarkMakeV4RandPool(b *testing.B) {
+	EnableRandPool()
+	defer DisableRandPool()
+	b.SetBytes(16)
+	for n := b.N; n > 0; n-- {
+		_ = MakeV4()
+	}
+}
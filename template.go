@@ -0,0 +1,25 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// FuncMap returns template functions for use with text/template and
+// html/template:
+//
+//	uuid4        a new Version 4 UUID
+//	uuid7        a new Version 7 UUID
+//	parse s      the UUID parsed from s, failing the template on error
+//	short u      the first 8 hex digits of u
+//
+// The result can be passed directly to Template.Funcs.
+func FuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"uuid4": MakeV4,
+		"uuid7": MakeV7,
+		"parse": Parse,
+		"short": func(uuid Uuid) string {
+			return uuid.String()[:8]
+		},
+	}
+}
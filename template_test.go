@@ -0,0 +1,39 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	const text = `{{uuid4 | short}} {{(uuid7).Version}} {{parse "9b78d54c-8cc9-46bc-ae29-efcba10e1abb"}}`
+	var buf strings.Builder
+	tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(text))
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	fields := strings.Fields(buf.String())
+	if len(fields) != 3 || len(fields[0]) != 8 || fields[1] != "7" || fields[2] != "9b78d54c-8cc9-46bc-ae29-efcba10e1abb" {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+
+	buf.Reset()
+	htmpl := htmltemplate.Must(htmltemplate.New("").Funcs(FuncMap()).Parse(`<div id="{{uuid4}}"></div>`))
+	if err := htmpl.Execute(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(buf.String()) != len(`<div id=""></div>`)+36 {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+
+	tmpl = template.Must(template.New("").Funcs(FuncMap()).Parse(`{{parse "bogus"}}`))
+	if err := tmpl.Execute(&buf, nil); err == nil {
+		t.Fatal("parse of a malformed UUID should fail the template")
+	}
+}
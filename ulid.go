@@ -0,0 +1,85 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var errULID = errors.New("uuid: invalid ULID")
+
+// crockfordDec maps characters to their Crockford base32 value, or 0xff for
+// characters outside the alphabet. Lower case letters are accepted.
+var crockfordDec = func() (dec [256]byte) {
+	for i := range dec {
+		dec[i] = 0xff
+	}
+	for i := 0; i < len(crockford); i++ {
+		dec[crockford[i]] = byte(i)
+		dec[crockford[i]|0x20] = byte(i)
+	}
+	return dec
+}()
+
+// appendBase32 appends the 128 bits of uuid to b as 26 characters of the
+// given base32 alphabet, most significant first.
+func (uuid Uuid) appendBase32(b []byte, alphabet string) []byte {
+	hi := binary.BigEndian.Uint64(uuid[0:])
+	lo := binary.BigEndian.Uint64(uuid[8:])
+	var buf [26]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = alphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return append(b, buf[:]...)
+}
+
+// decodeBase32 decodes 26 Crockford base32 characters produced by
+// appendBase32.
+func decodeBase32(s string) (Uuid, bool) {
+	// The first character only carries 3 bits.
+	if len(s) != 26 || crockfordDec[s[0]] > 7 {
+		return nil, false
+	}
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := crockfordDec[s[i]]
+		if v == 0xff {
+			return nil, false
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	uuid := Make()
+	binary.BigEndian.PutUint64(uuid[0:], hi)
+	binary.BigEndian.PutUint64(uuid[8:], lo)
+	return uuid, true
+}
+
+// ToULID returns uuid as a 26-character ULID. A ULID is a 48-bit
+// millisecond timestamp followed by 80 random bits, so the ULID of a
+// Version 7 UUID carries the same timestamp. The mapping is a plain
+// re-encoding of the 128 bits and is lossless for all versions.
+func (uuid Uuid) ToULID() string {
+	if len(uuid) != 16 {
+		panic("invalid uuid: not 16 bytes")
+	}
+	return string(uuid.appendBase32(make([]byte, 0, 26), crockford))
+}
+
+// FromULID decodes a 26-character ULID, in upper or lower case. The result
+// is a Version 7 UUID only if the ULID was produced by ToULID from one.
+func FromULID(s string) (Uuid, error) {
+	uuid, ok := decodeBase32(s)
+	if !ok {
+		return nil, errULID
+	}
+	return uuid, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestULID(t *testing.T) {
+	// The example from the ULID specification and its UUID form.
+	const ulid = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	id := Uuid{0x01, 0x56, 0x3e, 0x3a, 0xb5, 0xd3, 0xd6, 0x76, 0x4c, 0x61, 0xef, 0xb9, 0x93, 0x02, 0xbd, 0x5b}
+	if s := id.ToULID(); s != ulid {
+		t.Fatalf("want %s got %s", ulid, s)
+	}
+	for _, s := range []string{ulid, strings.ToLower(ulid)} {
+		id2, err := FromULID(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !id.Equal(id2) {
+			t.Fatalf("want %v got %v", id, id2)
+		}
+	}
+	bad := []string{
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAVV",
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU",
+	}
+	for _, s := range bad {
+		if _, err := FromULID(s); err != errULID {
+			t.Fatalf("decoding of %s should have failed", s)
+		}
+	}
+	max := Uuid{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	if s := max.ToULID(); s != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Fatalf("unexpected maximum ULID %s", s)
+	}
+}
+
+func TestULIDV7(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		id := MakeV7()
+		s := id.ToULID()
+		id2, err := FromULID(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !id.Equal(id2) || id2.Version() != 7 {
+			t.Fatalf("want %v got %v", id, id2)
+		}
+		// The first 10 characters of a ULID encode its timestamp.
+		ts, _ := id.Time()
+		ms := uint64(ts.UnixMilli())
+		var enc [10]byte
+		for j := 9; j >= 0; j-- {
+			enc[j] = crockford[ms&31]
+			ms >>= 5
+		}
+		if s[:10] != string(enc[:]) {
+			t.Fatalf("ULID %s does not carry the timestamp of %v", s, id)
+		}
+	}
+}
@@ -9,116 +9,256 @@ package uuid
 
 import (
 	"bytes"
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
-	"io"
-	"sync"
+	"fmt"
+	"strings"
 )
 
+// Uuid is a UUID as a 16-byte slice. Like any slice, assigning a Uuid
+// shares its bytes rather than copying them, so a change through one copy,
+// such as the in-place RandV4 method or an Unmarshal into the same
+// backing array, shows through all of them. Functions in this package
+// return Uuids that the caller owns and never modify their arguments,
+// except for methods documented to do so. Use Clone to take a private copy
+// of a Uuid that others may modify, or store a UuidKey, which is copied by
+// assignment. Neither the compiler nor go vet can tell a shared Uuid from
+// an owned one, so code that must not see later changes should hold
+// UuidKey values rather than rely on cloning.
 type Uuid []byte
 
+// Clone returns a copy of uuid that shares no memory with it. It returns
+// nil if uuid is nil.
+func (uuid Uuid) Clone() Uuid {
+	if uuid == nil {
+		return nil
+	}
+	return append(Uuid{}, uuid...)
+}
+
 func Make() Uuid {
 	return make(Uuid, 16)
 }
 
-var stream cipher.Stream
-var streamLock sync.Mutex
+// NewV4 makes a Version 4 (random data based) UUID with the default
+// generator. Unlike MakeV4 it returns an error if random data cannot be read.
+func NewV4() (Uuid, error) {
+	return defaultGenerator().NewV4()
+}
 
-func init() {
-	InitState()
+// Make Version 4 (random data based) UUID.
+func MakeV4() Uuid {
+	return mustMake(defaultGenerator().NewV4())
 }
 
-func InitState() {
-	// select AES-256
-	key := make([]byte, 32)
-	if _, err := io.ReadFull(rand.Reader, key); err != nil {
-		panic(err)
+var errParseFailed = errors.New("uuid: Parse: invalid value")
+
+// Parse decodes a UUID written in any of these forms, with hex digits in
+// either case:
+//
+//	9b78d54c-8cc9-46bc-ae29-efcba10e1abb
+//	{9b78d54c-8cc9-46bc-ae29-efcba10e1abb}
+//	urn:uuid:9b78d54c-8cc9-46bc-ae29-efcba10e1abb
+//	9b78d54c8cc946bcae29efcba10e1abb
+//	{0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0xbb}}
+//
+// The last is the C initializer form found in headers and registry exports.
+// Its elements may be surrounded by spaces and tabs, the 0x prefix may be
+// upper case, and leading zeros may be left out. The version must be one
+// of 1 through 8, defined by RFC 9562, unless the UUID is the Nil UUID; the
+// variant is not checked, see ParseStrict.
+func Parse(str string) (Uuid, error) {
+	uuid := Make()
+	if err := parse((*[16]byte)(uuid), str); err != nil {
+		countParseFailure()
+		return nil, err
 	}
+	return uuid, nil
+}
 
-	streamLock.Lock()
-	defer streamLock.Unlock()
+var errInvalidVariant = errors.New("uuid: Parse: invalid variant")
 
-	block, err := aes.NewCipher(key)
+// ParseStrict is like Parse but also requires the RFC 4122 variant, which
+// every UUID defined by RFC 9562 uses, except the Nil UUID. Use it to
+// validate external input, where Parse would accept any variant bits.
+func ParseStrict(str string) (Uuid, error) {
+	uuid, err := Parse(str)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-
-	iv := make([]byte, aes.BlockSize)
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
-		panic(err)
+	if uuid.Variant() != VariantRFC4122 && !uuid.isNil() {
+		countParseFailure()
+		return nil, errInvalidVariant
 	}
-	stream = cipher.NewCTR(block, iv)
+	return uuid, nil
 }
 
-// Make Version 4 (random data based) UUID.
-func MakeV4() Uuid {
-	// V4 UUID is of the form: xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx
-	// where x is any hexadecimal digit and y is one of 8, 9, A, or B.
-	id := make(Uuid, 16)
-
-	streamLock.Lock()
-	stream.XORKeyStream(id, id)
-	streamLock.Unlock()
-
-	// Set the four most significant bits (bits 12 through 15) of the
-	// time_hi_and_version field to the 4-bit version number from
-	// Section 4.1.3.
-	id[6] = (id[6] & 0xf) | 0x40
-
-	// Set the two most significant bits (bits 6 and 7) of the
-	// clock_seq_hi_and_reserved to zero and one, respectively.
-	id[8] = (id[8] & 0x3f) | 0x80
-
-	return id
+// ParseKey is like Parse but decodes into a UuidKey, so that it does not
+// allocate.
+func ParseKey(str string) (UuidKey, error) {
+	var key UuidKey
+	if err := parse((*[16]byte)(&key), str); err != nil {
+		countParseFailure()
+		return UuidKey{}, err
+	}
+	return key, nil
 }
 
-var errParseFailed = errors.New("uuid: Parse: invalid value")
-
-func Parse(str string) (Uuid, error) {
+// parse decodes str into uuid, accepting the forms documented on Parse.
+func parse(uuid *[16]byte, str string) error {
+	if len(str) > 45 && str[0] == '{' {
+		if !parseInitializer(uuid, str) {
+			return errParseFailed
+		}
+		return checkVersion(uuid)
+	}
+	if len(str) == 45 {
+		if !strings.EqualFold(str[:9], urnPrefix) {
+			return errParseFailed
+		}
+		str = str[9:]
+	}
 	if len(str) == 38 {
 		if str[0] != '{' || str[37] != '}' {
-			return nil, errParseFailed
+			return errParseFailed
 		}
 		str = str[1:37]
 	}
-	if len(str) != 36 {
-		return nil, errParseFailed
+	// 32 hex digits without hyphens are accepted as well.
+	if len(str) != 36 && len(str) != 32 {
+		return errParseFailed
 	}
-	uuid := Make()
-	j := 0
-	for i, c := range str {
-		if i == 8 || i == 13 || i == 18 || i == 23 {
-			if c != '-' {
-				return nil, errParseFailed
-			}
-			continue
+	offsets := &dashlessOffsets
+	if len(str) == 36 {
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			return errParseFailed
 		}
-		var v byte
-		if c >= '0' && c <= '9' {
-			v = byte(c - '0')
-		} else if c >= 'a' && c <= 'f' {
-			v = 10 + byte(c-'a')
-		} else if c >= 'A' && c <= 'F' {
-			v = 10 + byte(c-'A')
-		} else {
-			return nil, errParseFailed
-		}
-		if j&0x1 == 0 {
-			uuid[j>>1] = v << 4
-		} else {
-			uuid[j>>1] |= v
+		offsets = &canonicalOffsets
+	}
+	// Invalid digits decode to 0xff, so one check covers both of them.
+	var bad byte
+	for i, off := range offsets {
+		hi, lo := unhex[str[off]], unhex[str[off+1]]
+		bad |= hi | lo
+		uuid[i] = hi<<4 | lo
+	}
+	if bad&0xf0 != 0 {
+		return errParseFailed
+	}
+	return checkVersion(uuid)
+}
+
+// checkVersion rejects versions other than those RFC 9562 defines, 1
+// through 8, except in the Nil UUID.
+func checkVersion(uuid *[16]byte) error {
+	if version := uuid[6] >> 4; (version < 1 || version > 8) && *uuid != [16]byte{} {
+		return errParseFailed
+	}
+	return nil
+}
+
+// parseInitializer decodes the C initializer form of a GUID found in
+// headers and registry exports, such as
+// {0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0xbb}}.
+// Spaces may surround the elements and leading zeros may be left out.
+func parseInitializer(uuid *[16]byte, str string) bool {
+	p := initializerParser{s: str}
+	p.expect('{')
+	p.field(uuid[0:4])
+	p.expect(',')
+	p.field(uuid[4:6])
+	p.expect(',')
+	p.field(uuid[6:8])
+	p.expect(',')
+	p.expect('{')
+	for i := 8; i < 16; i++ {
+		if i > 8 {
+			p.expect(',')
 		}
-		j++
+		p.field(uuid[i : i+1])
 	}
-	version := uuid.Version()
-	if version < 1 || version > 5 {
-		return nil, errParseFailed
+	p.expect('}')
+	p.expect('}')
+	p.skipSpace()
+	return !p.failed && p.s == ""
+}
+
+// initializerParser scans a C initializer. After the first error failed
+// is set and the rest of the input is dropped, so further calls fail too.
+type initializerParser struct {
+	s      string
+	failed bool
+}
+
+func (p *initializerParser) fail() {
+	p.s = ""
+	p.failed = true
+}
+
+func (p *initializerParser) skipSpace() {
+	for len(p.s) > 0 && (p.s[0] == ' ' || p.s[0] == '\t') {
+		p.s = p.s[1:]
 	}
-	return uuid, nil
+}
+
+func (p *initializerParser) expect(c byte) {
+	p.skipSpace()
+	if len(p.s) == 0 || p.s[0] != c {
+		p.fail()
+		return
+	}
+	p.s = p.s[1:]
+}
+
+// field decodes a hex literal of at most len(dst) bytes into dst, in
+// big-endian order.
+func (p *initializerParser) field(dst []byte) {
+	p.skipSpace()
+	if len(p.s) < 3 || p.s[0] != '0' || p.s[1]|0x20 != 'x' {
+		p.fail()
+		return
+	}
+	p.s = p.s[2:]
+	var v uint64
+	n := 0
+	for n < len(p.s) && unhex[p.s[n]] != 0xff {
+		v = v<<4 | uint64(unhex[p.s[n]])
+		n++
+	}
+	if n == 0 || n > 2*len(dst) {
+		p.fail()
+		return
+	}
+	p.s = p.s[n:]
+	for i := len(dst) - 1; i >= 0; i-- {
+		dst[i] = byte(v)
+		v >>= 8
+	}
+}
+
+// isNil reports whether uuid is the Nil UUID, with all 128 bits set to zero.
+func (uuid Uuid) isNil() bool {
+	for _, b := range uuid {
+		if b != 0 {
+			return false
+		}
+	}
+	return len(uuid) == 16
+}
+
+// IsZero reports whether uuid is empty or the Nil UUID. It lets the
+// omitzero option of encoding/json, and ORMs that check for zero values,
+// treat both as unset.
+func (uuid Uuid) IsZero() bool {
+	return len(uuid) == 0 || uuid.isNil()
+}
+
+// IsZero reports whether key is the Nil UUID.
+func (key UuidKey) IsZero() bool {
+	return key == UuidKey{}
 }
 
 func MustParse(str string) Uuid {
@@ -129,68 +269,269 @@ func MustParse(str string) Uuid {
 	return id
 }
 
+// PanicOnInvalid makes Version, Variant and String panic if the Uuid is not
+// 16 bytes long, as they did originally. By default Version returns 0,
+// Variant -1 and String "<invalid uuid>" instead, so that malformed input
+// cannot crash a program that only logs or inspects it.
+//
+// Methods that convert a Uuid to another encoding or type, such as
+// AppendFormat, URN, Braced, UpperString, HexString, Prefixed, NCName,
+// Proquint, CheckCode, ToULID, ToMSSQL, ToBinOrdered, GUID, Uint64Pair,
+// BigInt, TraceID, SpanID and CombTime, still panic on a Uuid of the wrong
+// length regardless of PanicOnInvalid. Check the length, or use StringOK,
+// VersionOK and VariantOK, before calling them on untrusted values.
+var PanicOnInvalid = false
+
+// Version returns the version of uuid, or 0 if uuid is not 16 bytes long.
 func (uuid Uuid) Version() int {
-	if len(uuid) != 16 {
+	v, ok := uuid.VersionOK()
+	if !ok && PanicOnInvalid {
 		panic("invalid uuid: not 16 bytes")
 	}
-	return int(uuid[6] >> 4)
+	return v
+}
+
+// VersionOK returns the version of uuid. The result is false if uuid is not
+// 16 bytes long.
+func (uuid Uuid) VersionOK() (int, bool) {
+	if len(uuid) != 16 {
+		return 0, false
+	}
+	return int(uuid[6] >> 4), true
+}
+
+// Variants as defined in RFC 4122 section 4.1.1.
+const (
+	VariantNCS = iota
+	VariantRFC4122
+	VariantMicrosoft
+	VariantFuture
+)
+
+var variantNames = [...]string{"NCS", "RFC 4122", "Microsoft", "Future"}
+
+// Variant returns the variant of uuid, or -1 if uuid is not 16 bytes long.
+func (uuid Uuid) Variant() int {
+	v, ok := uuid.VariantOK()
+	if !ok {
+		if PanicOnInvalid {
+			panic("invalid uuid: not 16 bytes")
+		}
+		return -1
+	}
+	return v
+}
+
+// VariantOK returns the variant of uuid. The result is false if uuid is not
+// 16 bytes long.
+func (uuid Uuid) VariantOK() (int, bool) {
+	if len(uuid) != 16 {
+		return 0, false
+	}
+	switch {
+	case uuid[8]&0x80 == 0x00:
+		return VariantNCS, true
+	case uuid[8]&0xc0 == 0x80:
+		return VariantRFC4122, true
+	case uuid[8]&0xe0 == 0xc0:
+		return VariantMicrosoft, true
+	}
+	return VariantFuture, true
 }
 
 func (uuid Uuid) Equal(other Uuid) bool {
 	return bytes.Equal(uuid, other)
 }
 
+// EqualConstantTime reports whether uuid and other are equal in time that
+// depends only on their lengths, not their contents. Use it when a UUID is
+// a secret, such as a password reset or unsubscribe token.
+func (uuid Uuid) EqualConstantTime(other Uuid) bool {
+	return subtle.ConstantTimeCompare(uuid, other) == 1
+}
+
 func (uuid Uuid) Less(other Uuid) bool {
 	return bytes.Compare(uuid, other) == -1
 }
 
 var lut = [16]byte{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
+var lutUpper = [16]byte{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F'}
+
+// hexPairs and hexPairsUpper hold the two hex digits of every byte value,
+// so that each byte is encoded with a single lookup.
+var hexPairs, hexPairsUpper = makeHexPairs(&lut), makeHexPairs(&lutUpper)
+
+func makeHexPairs(digits *[16]byte) *[256][2]byte {
+	var pairs [256][2]byte
+	for i := range pairs {
+		pairs[i] = [2]byte{digits[i>>4], digits[i&0xf]}
+	}
+	return &pairs
+}
+
+// unhex maps hex digits of either case to their values and every other
+// byte to 0xff.
+var unhex = func() (t [256]byte) {
+	for i := range t {
+		t[i] = 0xff
+	}
+	for i, c := range lut {
+		t[c], t[lutUpper[i]] = byte(i), byte(i)
+	}
+	return t
+}()
+
+// canonicalOffsets and dashlessOffsets are the positions of the hex digit
+// pairs of each byte in the two textual forms.
+var (
+	canonicalOffsets = [16]int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34}
+	dashlessOffsets  = [16]int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30}
+)
+
+// LegacyEmptyString restores the original "<empty uuid>" string for an empty
+// Uuid in String, AppendText and MarshalText. By default an empty Uuid
+// formats as the Nil UUID, which every consumer can parse. The sentinel is
+// accepted when decoding either way, so stored values remain readable.
+var LegacyEmptyString = false
+
+const nilString = "00000000-0000-0000-0000-000000000000"
+
+// emptyString returns the string form of an empty Uuid.
+func emptyString() string {
+	if LegacyEmptyString {
+		return "<empty uuid>"
+	}
+	return nilString
+}
 
+// String returns the canonical form of uuid, or of the Nil UUID if uuid is
+// empty. It formats into a stack buffer, so the only allocation is the
+// returned string; use AppendText to format into a caller's buffer without
+// allocating at all.
 func (uuid Uuid) String() string {
 	if len(uuid) == 0 {
-		return "<empty uuid>"
+		return emptyString()
+	}
+	s, ok := uuid.StringOK()
+	if !ok {
+		if PanicOnInvalid {
+			panic("invalid uuid: not 16 bytes")
+		}
+		return "<invalid uuid>"
 	}
+	return s
+}
+
+// StringOK returns the canonical form of uuid. The result is false if uuid
+// is not 16 bytes long, including if it is empty.
+func (uuid Uuid) StringOK() (string, bool) {
 	if len(uuid) != 16 {
-		panic("invalid uuid: not 16 bytes")
+		return "", false
 	}
-	b := make([]byte, 36)
-	j := 0
-	for i := 0; i < len(uuid); i++ {
-		b[j] = lut[uuid[i]>>4]
-		j++
-		b[j] = lut[uuid[i]&0xf]
-		j++
-		if j == 8 || j == 13 || j == 18 || j == 23 {
-			b[j] = '-'
-			j++
+	var buf [36]byte
+	return string(uuid.appendCanonical(buf[:0])), true
+}
+
+// appendCanonical appends the 36-character canonical form of uuid to b.
+func (uuid Uuid) appendCanonical(b []byte) []byte {
+	return uuid.appendHex(b, hexPairs, true)
+}
+
+// appendHex appends the hex digits of uuid, which must be 16 bytes long, to
+// b using the given digit pair table, with or without the hyphens of the
+// canonical form.
+func (uuid Uuid) appendHex(b []byte, pairs *[256][2]byte, dashes bool) []byte {
+	_ = uuid[15]
+	var buf [36]byte
+	if !dashes {
+		for i, off := range dashlessOffsets {
+			*(*[2]byte)(buf[off:]) = pairs[uuid[i]]
 		}
+		return append(b, buf[:32]...)
 	}
-	return string(b)
+	for i, off := range canonicalOffsets {
+		*(*[2]byte)(buf[off:]) = pairs[uuid[i]]
+	}
+	buf[8], buf[13], buf[18], buf[23] = '-', '-', '-', '-'
+	return append(b, buf[:]...)
 }
 
 func (this Uuid) Compare(other Uuid) int {
 	return bytes.Compare(this, other)
 }
 
+// JSONFormat selects the string representation written by MarshalJSON.
+// FormatBinary writes the 16 raw bytes in standard base64, as encoding/json
+// does for []byte.
+var JSONFormat = FormatCanonical
+
+// MarshalJSON encodes an empty Uuid as null and any other Uuid as a string
+// in JSONFormat.
 func (uuid Uuid) MarshalJSON() ([]byte, error) {
-	return json.Marshal(uuid.String())
+	return uuid.AppendJSON(make([]byte, 0, 47))
 }
 
+// AppendJSON appends the encoding written by MarshalJSON to b. None of the
+// formats need escaping, so it writes the string directly without
+// allocating if b has enough capacity.
+func (uuid Uuid) AppendJSON(b []byte) ([]byte, error) {
+	if len(uuid) == 0 {
+		return append(b, "null"...), nil
+	}
+	if len(uuid) != 16 {
+		return b, errInvalidLength
+	}
+	b = append(b, '"')
+	if JSONFormat == FormatBinary {
+		b = base64.StdEncoding.AppendEncode(b, uuid)
+	} else {
+		b = uuid.AppendFormat(b, JSONFormat)
+	}
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON accepts any string written by MarshalJSON regardless of
+// JSONFormat. It decodes null, the empty string and the legacy
+// "<empty uuid>" string (see LegacyEmptyString) to an empty Uuid.
 func (uuid *Uuid) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*uuid = nil
+		return nil
+	}
 	var s string
 	err := json.Unmarshal(data, &s)
 	if err != nil {
 		return err
 	}
 
-	if s == "<empty uuid>" {
+	if s == "" || s == "<empty uuid>" {
 		*uuid = nil
 		return nil
 	}
+	if enc := base64Encoding(len(s)); enc != nil {
+		var buf [18]byte
+		if n, err := enc.Decode(buf[:], []byte(s)); err != nil || n != 16 {
+			return errParseFailed
+		}
+		*uuid = Uuid(buf[:16])
+		return nil
+	}
 	*uuid, err = Parse(s)
 	return err
 }
 
+// base64Encoding returns the encoding of a base64 encoded UUID of length n,
+// or nil if no base64 encoding of 16 bytes has that length.
+func base64Encoding(n int) *base64.Encoding {
+	switch n {
+	case base64.StdEncoding.EncodedLen(16):
+		return base64.StdEncoding
+	case base64.RawURLEncoding.EncodedLen(16):
+		return base64.RawURLEncoding
+	}
+	return nil
+}
+
 func NewPopulatedUuid(r int63) *Uuid {
 	u := RandV4(r)
 	return &u
@@ -205,11 +546,16 @@ func (uuid Uuid) MarshalTo(data []byte) (n int, err error) {
 	return 16, nil
 }
 
+// Unmarshal decodes the 16 raw bytes of a UUID. Empty data decodes to an
+// empty Uuid; any other length is an error.
 func (uuid *Uuid) Unmarshal(data []byte) error {
-	if data == nil {
-		uuid = nil
+	if len(data) == 0 {
+		*uuid = nil
 		return nil
 	}
+	if len(data) != 16 {
+		return fmt.Errorf("uuid: Unmarshal: got %d bytes, want 16: %w", len(data), errInvalidLength)
+	}
 	id := Uuid(make([]byte, 16))
 	copy(id, data)
 	*uuid = id
@@ -237,6 +583,11 @@ func (uuid Uuid) RandV4(r int63) {
 	uuid[8] = (uuid[8] & 0x3f) | 0x80
 }
 
+// UuidKey is the value form of a UUID. Unlike Uuid it is comparable, can
+// be used as a map key, cannot be nil or the wrong length, and does not
+// alias the memory it was made from. Code that keeps UUIDs around, rather
+// than passing them through, can store UuidKey and convert with Uuid and
+// Key at the edges. Uuid remains the primary type for compatibility.
 type UuidKey [16]byte
 
 func (uuid Uuid) Key() UuidKey {
@@ -261,10 +612,56 @@ func (this UuidKey) Compare(other UuidKey) int {
 	return bytes.Compare(this[:], other[:])
 }
 
+// Version returns the version of the UUID.
+func (key UuidKey) Version() int {
+	return key.Uuid().Version()
+}
+
+// Variant returns the variant of the UUID.
+func (key UuidKey) Variant() int {
+	return key.Uuid().Variant()
+}
+
+// Equal reports whether key and other are the same UUID. It is the same as
+// key == other.
+func (key UuidKey) Equal(other UuidKey) bool {
+	return key == other
+}
+
+// Less reports whether key sorts before other.
+func (key UuidKey) Less(other UuidKey) bool {
+	return key.Compare(other) < 0
+}
+
+// UnmarshalJSON accepts anything Uuid.UnmarshalJSON does. Values that
+// decode to an empty Uuid, such as null, give the zero key.
+func (key *UuidKey) UnmarshalJSON(data []byte) error {
+	var id Uuid
+	if err := id.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	return key.set(id)
+}
+
+// set stores id in key, mapping an empty Uuid to the zero key.
+func (key *UuidKey) set(id Uuid) error {
+	if len(id) != 0 && len(id) != 16 {
+		return errInvalidLength
+	}
+	*key = UuidKey{}
+	copy(key[:], id)
+	return nil
+}
+
+// Uint64 returns the first 8 bytes of uuid as a little-endian integer, or
+// 0 if uuid is shorter than that. It is kept for compatibility, such as
+// hashing into shards; the other 8 bytes are ignored. Use High64 and Low64
+// for the halves of the UUID read as a 128-bit big-endian integer.
 func (uuid Uuid) Uint64() uint64 {
-	var v uint64
-	binary.Read(bytes.NewBuffer([]byte(uuid)), binary.LittleEndian, &v)
-	return v
+	if len(uuid) < 8 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(uuid)
 }
 
 func putLittleEndianUint64(b []byte, offset int, v uint64) {
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -85,6 +86,11 @@ func TestParseGood(t *testing.T) {
 	good := []string{
 		"9ABCDEF0-8cc9-46bc-ae29-efcba10e1abb",
 		"{9ABCDEF0-8cc9-46bc-ae29-efcba10e1abb}",
+		"00000000-0000-0000-0000-000000000000",
+		// RFC 9562 versions
+		"1ec9414c-232a-6b00-b3c8-9e6bdeced846",
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		"2489e9ad-2ee2-8e00-8ec9-32d5f69181c0",
 	}
 	for _, str := range good {
 		if _, err := Parse(str); err != nil {
@@ -93,6 +99,94 @@ func TestParseGood(t *testing.T) {
 	}
 }
 
+func TestParseInitializer(t *testing.T) {
+	expected := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	good := []string{
+		"{0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0xbb}}",
+		"{ 0x9B78D54C, 0x8CC9, 0x46BC, { 0xAE, 0x29, 0xEF, 0xCB, 0xA1, 0xE, 0x1A, 0xBB } }",
+		"{0X9b78d54c,0X8cc9,0X46bc,{0Xae,0X29,0Xef,0Xcb,0Xa1,0X0e,0X1a,0Xbb}}\t",
+	}
+	for _, str := range good {
+		id, err := Parse(str)
+		if err != nil {
+			t.Fatalf("Parsing of %s failed: %v", str, err)
+		}
+		if !id.Equal(expected) {
+			t.Fatalf("want %v got %v", expected, id)
+		}
+	}
+	bad := []string{
+		"{0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a}}",
+		"{0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0xbb,0x00}}",
+		"{0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0xbb}",
+		"{0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0xbb}}}",
+		"{0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0x1bb}}",
+		"{0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,bb}}",
+		"{0x9b78d54c,0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0x}}",
+		"{0x9b78d54c;0x8cc9,0x46bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0xbb}}",
+		"{0x9b78d54c,0x8cc9,0xf6bc,{0xae,0x29,0xef,0xcb,0xa1,0x0e,0x1a,0xbb}}",
+	}
+	for _, str := range bad {
+		if _, err := Parse(str); err != errParseFailed {
+			t.Fatalf("Parsing of %s should have failed", str)
+		}
+	}
+}
+
+func TestParseNil(t *testing.T) {
+	const str = "00000000-0000-0000-0000-000000000000"
+	uuid, err := Parse(str)
+	if err != nil {
+		t.Fatalf("Parsing of %s failed: %v", str, err)
+	}
+	if !uuid.Equal(Make()) {
+		t.Fatalf("want Nil UUID got %v", uuid)
+	}
+	if uuid.String() != str {
+		t.Fatalf("want %s got %s", str, uuid.String())
+	}
+}
+
+func TestClone(t *testing.T) {
+	id := MakeV4()
+	c := id.Clone()
+	if !c.Equal(id) {
+		t.Fatalf("want %v got %v", id, c)
+	}
+	c.RandV4(rand.New(rand.NewSource(1)))
+	if c.Equal(id) {
+		t.Fatal("Clone should not share memory")
+	}
+	if Uuid(nil).Clone() != nil || (Uuid{}).Clone() == nil {
+		t.Fatal("Clone should preserve nil")
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	for _, test := range []struct {
+		id   Uuid
+		zero bool
+	}{
+		{nil, true},
+		{Uuid{}, true},
+		{Make(), true},
+		{MakeV4(), false},
+		{Uuid{15: 1}, false},
+	} {
+		if test.id.IsZero() != test.zero || test.id.Key().IsZero() != test.zero {
+			t.Fatalf("%v: want %v", test.id, test.zero)
+		}
+	}
+	type item struct {
+		ID Uuid `json:"id,omitzero"`
+	}
+	for _, id := range []Uuid{nil, Make()} {
+		if b, err := json.Marshal(item{id}); err != nil || string(b) != "{}" {
+			t.Fatalf("unexpected JSON %s %v", b, err)
+		}
+	}
+}
+
 func TestParseErrors(t *testing.T) {
 	bad := []string{
 		"9b78d54c-8cc9-46bc-ae29-efcba10e1ab",
@@ -108,7 +202,9 @@ func TestParseErrors(t *testing.T) {
 		"9bP8d54c-8cc9-46bc-ae29-efcba10e1abb",
 		"9b78d54c-8cc9-46bc-ae29-efcba10e1abX",
 		"9ABCDEF0-8cc9-06bc-ae29-efcba10e1abb",
-		"9ABCDEF0-8cc9-66bc-ae29-efcba10e1abb",
+		"9ABCDEF0-8cc9-96bc-ae29-efcba10e1abb",
+		"9ABCDEF0-8cc9-f6bc-ae29-efcba10e1abb",
+		"00000000-0000-0000-0000-000000000001",
 	}
 	for _, str := range bad {
 		if _, err := Parse(str); err != errParseFailed {
@@ -117,6 +213,23 @@ func TestParseErrors(t *testing.T) {
 	}
 }
 
+func TestVariant(t *testing.T) {
+	tests := []struct {
+		str     string
+		variant int
+	}{
+		{"9b78d54c-8cc9-46bc-7e29-efcba10e1abb", VariantNCS},
+		{"9b78d54c-8cc9-46bc-ae29-efcba10e1abb", VariantRFC4122},
+		{"9b78d54c-8cc9-46bc-ce29-efcba10e1abb", VariantMicrosoft},
+		{"9b78d54c-8cc9-46bc-ee29-efcba10e1abb", VariantFuture},
+	}
+	for _, test := range tests {
+		if v := MustParse(test.str).Variant(); v != test.variant {
+			t.Fatalf("%s: want variant %d got %d", test.str, test.variant, v)
+		}
+	}
+}
+
 func TestProto(t *testing.T) {
 	id := MakeV4()
 	data, err := id.Marshal()
@@ -149,10 +262,91 @@ func TestJSON(t *testing.T) {
 	}
 }
 
+func TestJSONNull(t *testing.T) {
+	data, err := Uuid(nil).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "null" {
+		t.Fatalf("want null got %s", data)
+	}
+	for _, data := range []string{`null`, `""`, `"<empty uuid>"`} {
+		id := MakeV4()
+		if err := id.UnmarshalJSON([]byte(data)); err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+		if id != nil {
+			t.Fatalf("%s: want empty Uuid got %v", data, id)
+		}
+	}
+	m := &MyIdStruct{Id: MakeV4()}
+	if err := json.Unmarshal([]byte(`{"Id":null}`), m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Id != nil {
+		t.Fatalf("want empty Uuid got %v", m.Id)
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	defer func(format Format) { JSONFormat = format }(JSONFormat)
+	id := MustParse("9b78d54c-8cc9-46bc-ae29-efcba10e1abb")
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{FormatCanonical, `"9b78d54c-8cc9-46bc-ae29-efcba10e1abb"`},
+		{FormatBinary, `"m3jVTIzJRryuKe/LoQ4auw=="`},
+		{FormatBase64, `"m3jVTIzJRryuKe_LoQ4auw"`},
+		{FormatHex, `"9b78d54c8cc946bcae29efcba10e1abb"`},
+	}
+	for _, test := range tests {
+		JSONFormat = test.format
+		data, err := json.Marshal(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.expected {
+			t.Fatalf("format %d: want %s got %s", test.format, test.expected, data)
+		}
+		JSONFormat = FormatCanonical
+		var id2 Uuid
+		if err := json.Unmarshal(data, &id2); err != nil {
+			t.Fatalf("format %d: %v", test.format, err)
+		}
+		if !id.Equal(id2) {
+			t.Fatalf("format %d: want %v got %v", test.format, id, id2)
+		}
+	}
+	var id2 Uuid
+	for _, data := range []string{`"m3jVTIzJRryuKe/LoQ4au!=="`, `"AAAAAAAAAAAAAAAAAAAAAAAA"`} {
+		if err := json.Unmarshal([]byte(data), &id2); err != errParseFailed {
+			t.Fatalf("%s: want %v got %v", data, errParseFailed, err)
+		}
+	}
+}
+
 type MyIdStruct struct {
 	Id Uuid
 }
 
+func TestAppendJSONAllocs(t *testing.T) {
+	id := MakeV4()
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = id.AppendJSON(buf[:0])
+	})
+	if allocs != 0 {
+		t.Fatalf("want 0 allocs got %v", allocs)
+	}
+	if string(buf) != `"`+id.String()+`"` {
+		t.Fatalf("unexpected JSON %s", buf)
+	}
+	if _, err := (Uuid{1, 2}).AppendJSON(nil); err != errInvalidLength {
+		t.Fatalf("want %v got %v", errInvalidLength, err)
+	}
+}
+
 func TestJSONStruct(t *testing.T) {
 	m := &MyIdStruct{Id: MakeV4()}
 	data, err := json.Marshal(m)
@@ -179,6 +373,27 @@ func TestKey(t *testing.T) {
 	}
 }
 
+func TestKeyMethods(t *testing.T) {
+	a := MustParse("01890a5d-ac96-774b-bcce-b302099a8057").Key()
+	b := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479").Key()
+	if a.Version() != 7 || b.Version() != 4 || a.Variant() != VariantRFC4122 {
+		t.Fatalf("unexpected version or variant of %v %v", a, b)
+	}
+	if !a.Equal(a) || a.Equal(b) || !a.Less(b) || b.Less(a) {
+		t.Fatalf("unexpected comparison of %v %v", a, b)
+	}
+	var key UuidKey
+	if err := json.Unmarshal([]byte(`"01890a5d-ac96-774b-bcce-b302099a8057"`), &key); err != nil || key != a {
+		t.Fatalf("want %v got %v %v", a, key, err)
+	}
+	if err := json.Unmarshal([]byte("null"), &key); err != nil || key != (UuidKey{}) {
+		t.Fatalf("want zero key got %v %v", key, err)
+	}
+	if err := json.Unmarshal([]byte(`"nope"`), &key); err == nil {
+		t.Fatal("invalid string should fail")
+	}
+}
+
 func TestNewRandV4(t *testing.T) {
 	r := rand.New(rand.NewSource(0))
 	RandV4(r)
@@ -201,6 +416,24 @@ func TestMarshal(t *testing.T) {
 	}
 }
 
+func TestUnmarshal(t *testing.T) {
+	id := MakeV4()
+	var got Uuid
+	if err := got.Unmarshal(id); err != nil || !got.Equal(id) {
+		t.Fatalf("want %v got %v %v", id, got, err)
+	}
+	for _, data := range [][]byte{nil, {}} {
+		if err := got.Unmarshal(data); err != nil || got != nil {
+			t.Fatalf("want empty Uuid got %v %v", got, err)
+		}
+	}
+	for _, n := range []int{3, 15, 17, 20} {
+		if err := got.Unmarshal(make([]byte, n)); !errors.Is(err, errInvalidLength) {
+			t.Fatalf("%d bytes: want %v got %v", n, errInvalidLength, err)
+		}
+	}
+}
+
 func TestString(t *testing.T) {
 	id := Uuid{0, 1, 2, 3, 4, 5, 70, 7, 136, 9, 10, 11, 12, 13, 14, 15}
 	const expected = "00010203-0405-4607-8809-0a0b0c0d0e0f"
@@ -213,6 +446,123 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestEqualConstantTime(t *testing.T) {
+	a, b := MakeV4(), MakeV4()
+	if !a.EqualConstantTime(append(Uuid(nil), a...)) || a.EqualConstantTime(b) || a.EqualConstantTime(a[:8]) {
+		t.Fatalf("unexpected comparison of %v %v", a, b)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, s := range []string{
+		"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"00000000-0000-0000-0000-000000000000",
+	} {
+		if _, err := ParseStrict(s); err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+	}
+	for _, s := range []string{
+		"f47ac10b-58cc-4372-e567-0e02b2c3d479",
+		"f47ac10b-58cc-4372-c567-0e02b2c3d479",
+		"f47ac10b-58cc-4372-0567-0e02b2c3d479",
+	} {
+		if _, err := Parse(s); err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if _, err := ParseStrict(s); err != errInvalidVariant {
+			t.Fatalf("%s: want %v got %v", s, errInvalidVariant, err)
+		}
+	}
+	if _, err := ParseStrict("nope"); err != errParseFailed {
+		t.Fatalf("want %v got %v", errParseFailed, err)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	const s = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	key, err := ParseKey(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != MustParse(s).Key() {
+		t.Fatalf("want %s got %v", s, key)
+	}
+	if _, err := ParseKey("f47ac10b-58cc-4372-a567-0e02b2c3d47x"); err != errParseFailed {
+		t.Fatalf("want %v got %v", errParseFailed, err)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { key, _ = ParseKey(s) }); allocs != 0 {
+		t.Fatalf("want 0 allocs got %v", allocs)
+	}
+}
+
+func TestInvalidLength(t *testing.T) {
+	id := Uuid{1, 2, 3}
+	if v, ok := id.VersionOK(); ok || v != 0 {
+		t.Fatalf("unexpected result %d %v", v, ok)
+	}
+	if s, ok := id.StringOK(); ok || s != "" {
+		t.Fatalf("unexpected result %q %v", s, ok)
+	}
+	if v, ok := id.VariantOK(); ok || v != 0 {
+		t.Fatalf("unexpected result %d %v", v, ok)
+	}
+	if id.Version() != 0 || id.Variant() != -1 || id.String() != "<invalid uuid>" {
+		t.Fatalf("unexpected result %d %d %q", id.Version(), id.Variant(), id.String())
+	}
+	if s := fmt.Sprint(id); s != "<invalid uuid>" {
+		t.Fatalf("unexpected result %q", s)
+	}
+	valid := MakeV4()
+	if v, ok := valid.VersionOK(); !ok || v != 4 {
+		t.Fatalf("unexpected result %d %v", v, ok)
+	}
+	if v, ok := valid.VariantOK(); !ok || v != VariantRFC4122 {
+		t.Fatalf("unexpected result %d %v", v, ok)
+	}
+	if s, ok := valid.StringOK(); !ok || s != valid.String() {
+		t.Fatalf("unexpected result %q %v", s, ok)
+	}
+
+	defer func(p bool) { PanicOnInvalid = p }(PanicOnInvalid)
+	PanicOnInvalid = true
+	for _, f := range []func(){func() { id.Version() }, func() { id.Variant() }, func() { _ = id.String() }} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("PanicOnInvalid should panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestEmptyString(t *testing.T) {
+	var id Uuid
+	b, _ := id.MarshalText()
+	if id.String() != nilString || string(b) != nilString {
+		t.Fatalf("want %s got %q %q", nilString, id.String(), b)
+	}
+	defer func(l bool) { LegacyEmptyString = l }(LegacyEmptyString)
+	LegacyEmptyString = true
+	b, _ = id.MarshalText()
+	if id.String() != "<empty uuid>" || string(b) != "<empty uuid>" {
+		t.Fatalf("want legacy string got %q %q", id.String(), b)
+	}
+	var got Uuid = MakeV4()
+	if err := got.UnmarshalText(b); err != nil || got != nil {
+		t.Fatalf("want empty Uuid got %v %v", got, err)
+	}
+}
+
+func TestStringAllocs(t *testing.T) {
+	id := MakeV4()
+	if allocs := testing.AllocsPerRun(100, func() { _ = id.String() }); allocs != 1 {
+		t.Fatalf("want 1 alloc got %v", allocs)
+	}
+}
+
 func TestUint64(t *testing.T) {
 	b := make([]byte, 0, 16)
 	buf := new(bytes.Buffer)
@@ -287,8 +637,16 @@ func BenchmarkFmtSprintf(b *testing.B) {
 	}
 }
 
+func BenchmarkParse(b *testing.B) {
+	const s = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	for i := 0; i < b.N; i++ {
+		ParseKey(s)
+	}
+}
+
 func BenchmarkString(b *testing.B) {
 	id := MakeV4()
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		id.String()
 	}
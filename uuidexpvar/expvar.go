@@ -0,0 +1,31 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package uuidexpvar publishes the metrics of package uuid with expvar. It
+// is separate from package uuid because importing expvar registers a
+// handler on http.DefaultServeMux.
+package uuidexpvar
+
+import (
+	"expvar"
+
+	"github.com/alberts/uuid"
+)
+
+// Publish enables uuid metrics and publishes them under name, as a map with
+// the keys generated_v1, generated_v4, generated_v7, rekeys and
+// parse_failures. Like expvar.Publish, it panics if name is already in use.
+func Publish(name string) {
+	uuid.EnableMetrics()
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		m := uuid.ReadMetrics()
+		return map[string]uint64{
+			"generated_v1":   m.Generated[1],
+			"generated_v4":   m.Generated[4],
+			"generated_v7":   m.Generated[7],
+			"rekeys":         m.Rekeys,
+			"parse_failures": m.ParseFailures,
+		}
+	}))
+}
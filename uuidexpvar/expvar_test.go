@@ -0,0 +1,28 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuidexpvar
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/alberts/uuid"
+)
+
+func TestPublish(t *testing.T) {
+	Publish("uuid")
+	uuid.MakeV4()
+	uuid.Parse("nope")
+	var published map[string]uint64
+	if err := json.Unmarshal([]byte(expvar.Get("uuid").String()), &published); err != nil {
+		t.Fatal(err)
+	}
+	m := uuid.ReadMetrics()
+	if published["generated_v4"] != m.Generated[4] || published["generated_v4"] == 0 ||
+		published["parse_failures"] != 1 || published["rekeys"] == 0 {
+		t.Fatalf("unexpected expvar %v for %+v", published, m)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package uuidhttp serves freshly generated UUIDs over HTTP.
+package uuidhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alberts/uuid"
+)
+
+// DefaultMaxCount is the largest count a Handler serves if MaxCount is 0.
+const DefaultMaxCount = 1000
+
+// Handler is an http.Handler that responds with new UUIDs. The query
+// parameter count selects how many (default 1) and version selects the
+// version, 1, 4 (the default) or 7. The response is plain text, one UUID
+// per line, or a JSON array of strings if the format parameter is json or
+// the request accepts application/json.
+//
+//	curl 'http://localhost:8080/uuid?count=3&version=7'
+type Handler struct {
+	// Generator makes the UUIDs. If nil, the package default generator of
+	// uuid is used.
+	Generator uuid.Generator
+
+	// MaxCount limits count. If 0, DefaultMaxCount is used.
+	MaxCount int
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	count := 1
+	if s := q.Get("count"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > h.maxCount() {
+			http.Error(w, "count must be between 1 and "+strconv.Itoa(h.maxCount()), http.StatusBadRequest)
+			return
+		}
+		count = n
+	}
+	gen := h.generator(q.Get("version"))
+	if gen == nil {
+		http.Error(w, "version must be 1, 4 or 7", http.StatusBadRequest)
+		return
+	}
+
+	ids := make(uuid.Uuids, count)
+	for i := range ids {
+		id, err := gen()
+		if err != nil {
+			http.Error(w, "cannot generate UUID", http.StatusInternalServerError)
+			return
+		}
+		ids[i] = id
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if q.Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ids)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	b := make([]byte, 0, 37*count)
+	for _, id := range ids {
+		b = append(id.AppendFormat(b, uuid.FormatCanonical), '\n')
+	}
+	w.Write(b)
+}
+
+func (h *Handler) maxCount() int {
+	if h.MaxCount > 0 {
+		return h.MaxCount
+	}
+	return DefaultMaxCount
+}
+
+// generator returns the function making UUIDs of the given version, or nil
+// if it is not supported.
+func (h *Handler) generator(version string) func() (uuid.Uuid, error) {
+	g := h.Generator
+	switch version {
+	case "1":
+		if g != nil {
+			return g.NewV1
+		}
+		return uuid.NewV1
+	case "", "4":
+		if g != nil {
+			return g.NewV4
+		}
+		return uuid.NewV4
+	case "7":
+		if g != nil {
+			return g.NewV7
+		}
+		return uuid.NewV7
+	}
+	return nil
+}
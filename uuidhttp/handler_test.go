@@ -0,0 +1,81 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuidhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alberts/uuid"
+)
+
+var _ http.Handler = (*Handler)(nil)
+
+func get(h http.Handler, target string, header ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", target, nil)
+	for i := 0; i < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandlerText(t *testing.T) {
+	h := new(Handler)
+	w := get(h, "/")
+	id, err := uuid.Parse(strings.TrimSuffix(w.Body.String(), "\n"))
+	if w.Code != 200 || err != nil || id.Version() != 4 {
+		t.Fatalf("unexpected response %d %q", w.Code, w.Body)
+	}
+	w = get(h, "/?count=3&version=7")
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	if w.Code != 200 || len(lines) != 3 {
+		t.Fatalf("unexpected response %d %q", w.Code, w.Body)
+	}
+	for _, line := range lines {
+		if id, err := uuid.Parse(line); err != nil || id.Version() != 7 {
+			t.Fatalf("unexpected line %q", line)
+		}
+	}
+}
+
+func TestHandlerJSON(t *testing.T) {
+	h := &Handler{Generator: new(uuid.SequentialGenerator)}
+	for _, w := range []*httptest.ResponseRecorder{
+		get(h, "/?count=2&format=json"),
+		get(h, "/?count=2", "Accept", "application/json"),
+	} {
+		var ids uuid.Uuids
+		if err := json.Unmarshal(w.Body.Bytes(), &ids); err != nil || len(ids) != 2 {
+			t.Fatalf("unexpected response %q %v", w.Body, err)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Fatalf("unexpected content type %q", ct)
+		}
+	}
+	w := get(h, "/?version=1")
+	if s := w.Body.String(); s != "00000000-0000-1000-8000-000000000005\n" {
+		t.Fatalf("unexpected response %q", s)
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	h := &Handler{MaxCount: 10}
+	for _, target := range []string{"/?count=0", "/?count=11", "/?count=x", "/?version=3"} {
+		if w := get(h, target); w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: want 400 got %d", target, w.Code)
+		}
+	}
+	r := httptest.NewRequest("POST", "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("want 405 got %d", w.Code)
+	}
+}
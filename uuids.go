@@ -0,0 +1,361 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Search returns the index of uuid in ids, which must be sorted, or the
+// index where it would be inserted if it is not present.
+func (ids Uuids) Search(uuid Uuid) int {
+	return sort.Search(len(ids), func(i int) bool {
+		return !ids[i].Less(uuid)
+	})
+}
+
+// Contains reports whether the sorted ids contain uuid.
+func (ids Uuids) Contains(uuid Uuid) bool {
+	i := ids.Search(uuid)
+	return i < len(ids) && ids[i].Equal(uuid)
+}
+
+// Clone returns a deep copy of ids: the list and every UUID in it are
+// copied into new memory. Nil elements stay nil and empty ones stay empty
+// but non-nil.
+func (ids Uuids) Clone() Uuids {
+	if ids == nil {
+		return nil
+	}
+	data := make([]byte, 0, 16*len(ids))
+	out := make(Uuids, len(ids))
+	for i, id := range ids {
+		if id != nil {
+			start := len(data)
+			data = append(data, id...)
+			out[i] = Uuid(data[start:len(data):len(data)])
+		}
+	}
+	return out
+}
+
+// Dedupe removes duplicate UUIDs from ids in place, keeping the first
+// occurrence of each and their order, and returns the shortened slice.
+func (ids Uuids) Dedupe() Uuids {
+	seen := make(map[UuidKey]struct{}, len(ids))
+	out := ids[:0]
+	for _, id := range ids {
+		if _, ok := seen[id.Key()]; !ok {
+			seen[id.Key()] = struct{}{}
+			out = append(out, id)
+		}
+	}
+	clear(ids[len(out):])
+	return out
+}
+
+// SortUnique sorts ids in place, removes duplicates and returns the
+// shortened slice. It needs no extra memory, unlike Dedupe.
+func (ids Uuids) SortUnique() Uuids {
+	sort.Sort(ids)
+	out := ids[:0]
+	for i, id := range ids {
+		if i == 0 || !id.Equal(out[len(out)-1]) {
+			out = append(out, id)
+		}
+	}
+	clear(ids[len(out):])
+	return out
+}
+
+// Min returns the smallest UUID in ids, or nil if ids is empty. Unlike
+// Search, it does not need ids to be sorted.
+func (ids Uuids) Min() Uuid {
+	return MinOf(ids...)
+}
+
+// Max returns the largest UUID in ids, or nil if ids is empty.
+func (ids Uuids) Max() Uuid {
+	return MaxOf(ids...)
+}
+
+// MinOf returns the smallest of ids in byte order, or nil if there are
+// none. It accepts any UUID type based on []byte.
+func MinOf[U ~[]byte](ids ...U) U {
+	var m U
+	for i, id := range ids {
+		if i == 0 || bytes.Compare(id, m) < 0 {
+			m = id
+		}
+	}
+	return m
+}
+
+// MaxOf returns the largest of ids in byte order, or nil if there are none.
+func MaxOf[U ~[]byte](ids ...U) U {
+	var m U
+	for i, id := range ids {
+		if i == 0 || bytes.Compare(id, m) > 0 {
+			m = id
+		}
+	}
+	return m
+}
+
+// Union returns the UUIDs in a or b. Like Intersect and Difference, it takes
+// sorted lists without duplicates, such as those returned by SortUnique,
+// merges them in linear time and returns a new sorted list. The elements
+// are shared with a and b, not copied.
+func Union(a, b Uuids) Uuids {
+	out := make(Uuids, 0, max(len(a), len(b)))
+	for len(a) > 0 && len(b) > 0 {
+		switch c := bytes.Compare(a[0], b[0]); {
+		case c < 0:
+			out, a = append(out, a[0]), a[1:]
+		case c > 0:
+			out, b = append(out, b[0]), b[1:]
+		default:
+			out, a, b = append(out, a[0]), a[1:], b[1:]
+		}
+	}
+	out = append(out, a...)
+	return append(out, b...)
+}
+
+// Intersect returns the UUIDs in both a and b.
+func Intersect(a, b Uuids) Uuids {
+	out := make(Uuids, 0, min(len(a), len(b)))
+	for len(a) > 0 && len(b) > 0 {
+		switch c := bytes.Compare(a[0], b[0]); {
+		case c < 0:
+			a = a[1:]
+		case c > 0:
+			b = b[1:]
+		default:
+			out, a, b = append(out, a[0]), a[1:], b[1:]
+		}
+	}
+	return out
+}
+
+// Difference returns the UUIDs in a but not in b.
+func Difference(a, b Uuids) Uuids {
+	out := make(Uuids, 0, len(a))
+	for len(a) > 0 && len(b) > 0 {
+		switch c := bytes.Compare(a[0], b[0]); {
+		case c < 0:
+			out, a = append(out, a[0]), a[1:]
+		case c > 0:
+			b = b[1:]
+		default:
+			a, b = a[1:], b[1:]
+		}
+	}
+	return append(out, a...)
+}
+
+// ParseAll parses each of strs like Parse, for bulk imports. The UUIDs share
+// one allocation. If every string parses, errs is nil; otherwise errs has
+// an entry for each string, nil where it parsed, and the UUIDs of the
+// strings that failed are nil.
+func ParseAll(strs []string) (ids Uuids, errs []error) {
+	data := make([]byte, 16*len(strs))
+	ids = make(Uuids, len(strs))
+	for i, s := range strs {
+		id := data[16*i : 16*i+16 : 16*i+16]
+		if err := parse((*[16]byte)(id), s); err != nil {
+			countParseFailure()
+			if errs == nil {
+				errs = make([]error, len(strs))
+			}
+			errs[i] = err
+			continue
+		}
+		ids[i] = Uuid(id)
+	}
+	return ids, errs
+}
+
+// ParseAllFailFast is like ParseAll but stops at the first string that
+// does not parse, returning an error that includes its index.
+func ParseAllFailFast(strs []string) (Uuids, error) {
+	data := make([]byte, 16*len(strs))
+	ids := make(Uuids, len(strs))
+	for i, s := range strs {
+		id := data[16*i : 16*i+16 : 16*i+16]
+		if err := parse((*[16]byte)(id), s); err != nil {
+			countParseFailure()
+			return nil, fmt.Errorf("uuid: element %d: %w", i, err)
+		}
+		ids[i] = Uuid(id)
+	}
+	return ids, nil
+}
+
+// MarshalJSON encodes ids as an array of UUID strings in JSONFormat, like
+// Uuid.MarshalJSON, or null if ids is nil. Unlike a single Uuid, empty
+// elements are an error.
+func (ids Uuids) MarshalJSON() ([]byte, error) {
+	if ids == nil {
+		return []byte("null"), nil
+	}
+	b := make([]byte, 0, 2+len(ids)*47)
+	b = append(b, '[')
+	for i, id := range ids {
+		if len(id) != 16 {
+			return nil, fmt.Errorf("uuid: element %d: %w", i, errInvalidLength)
+		}
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b, _ = id.AppendJSON(b)
+	}
+	return append(b, ']'), nil
+}
+
+// UnmarshalJSON decodes an array of UUID strings in any form accepted by
+// Uuid.UnmarshalJSON, regardless of JSONFormat. Unlike a single Uuid, null
+// elements and the empty forms are rejected, so a malformed ID list fails
+// as a whole.
+func (ids *Uuids) UnmarshalJSON(data []byte) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if elems == nil {
+		*ids = nil
+		return nil
+	}
+	out := make(Uuids, len(elems))
+	for i, elem := range elems {
+		if err := out[i].UnmarshalJSON(elem); err != nil {
+			return fmt.Errorf("uuid: element %d: %w", i, err)
+		}
+		if len(out[i]) == 0 {
+			return fmt.Errorf("uuid: element %d: %w", i, errParseFailed)
+		}
+	}
+	*ids = out
+	return nil
+}
+
+// MarshalBinary encodes ids as their 16-byte values concatenated.
+func (ids Uuids) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 16*len(ids))
+	for i, id := range ids {
+		if len(id) != 16 {
+			return nil, fmt.Errorf("uuid: element %d: %w", i, errInvalidLength)
+		}
+		b = append(b, id...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the encoding written by MarshalBinary. The UUIDs
+// share one copy of data.
+func (ids *Uuids) UnmarshalBinary(data []byte) error {
+	if len(data)%16 != 0 {
+		return errInvalidLength
+	}
+	data = append([]byte(nil), data...)
+	out := make(Uuids, len(data)/16)
+	for i := range out {
+		out[i] = Uuid(data[16*i : 16*i+16 : 16*i+16])
+	}
+	*ids = out
+	return nil
+}
+
+// WriteTo writes ids to w as a uvarint count followed by the encoding of
+// MarshalBinary, so that several lists can be written to one stream.
+func (ids Uuids) WriteTo(w io.Writer) (int64, error) {
+	for i, id := range ids {
+		if len(id) != 16 {
+			return 0, fmt.Errorf("uuid: element %d: %w", i, errInvalidLength)
+		}
+	}
+	buf := writeBufPool.Get().(*[writeBufSize]byte)
+	defer writeBufPool.Put(buf)
+	b := binary.AppendUvarint(buf[:0], uint64(len(ids)))
+	var total int64
+	for _, id := range ids {
+		if len(b)+16 > len(buf) {
+			n, err := w.Write(b)
+			total += int64(n)
+			if err != nil {
+				return total, err
+			}
+			b = buf[:0]
+		}
+		b = append(b, id...)
+	}
+	n, err := w.Write(b)
+	return total + int64(n), err
+}
+
+const writeBufSize = 4096
+
+// writeBufPool holds the buffers WriteTo writes through, so that writing
+// large lists neither allocates a copy of the list nor churns the GC.
+var writeBufPool = sync.Pool{
+	New: func() interface{} { return new([writeBufSize]byte) },
+}
+
+// ReadFrom replaces ids with a list read from r in the format written by
+// WriteTo. It reads no further than the end of the list.
+func (ids *Uuids) ReadFrom(r io.Reader) (int64, error) {
+	br := &countingByteReader{r: r}
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		if err == io.EOF && br.n > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return br.n, err
+	}
+	// Grow the list as data arrives rather than trusting count up front.
+	var out Uuids
+	for count > 0 {
+		chunk := min(count, 4096)
+		data := make([]byte, 16*chunk)
+		n, err := io.ReadFull(r, data)
+		br.n += int64(n)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return br.n, err
+		}
+		for i := 0; i < len(data); i += 16 {
+			out = append(out, Uuid(data[i:i+16:i+16]))
+		}
+		count -= chunk
+	}
+	if out == nil {
+		out = Uuids{}
+	}
+	*ids = out
+	return br.n, nil
+}
+
+// countingByteReader reads single bytes from r, counting them.
+type countingByteReader struct {
+	r   io.Reader
+	n   int64
+	buf [1]byte
+}
+
+func (r *countingByteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(r.r, r.buf[:]); err != nil {
+		return 0, err
+	}
+	r.n++
+	return r.buf[0], nil
+}
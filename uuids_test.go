@@ -0,0 +1,342 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	ids := make(Uuids, 100)
+	for i := range ids {
+		ids[i] = MakeV4()
+	}
+	sort.Sort(ids)
+	for i, id := range ids {
+		if j := ids.Search(id); j != i {
+			t.Fatalf("want %d got %d", i, j)
+		}
+		if !ids.Contains(id) {
+			t.Fatalf("%v not found", id)
+		}
+	}
+	min, max := MustParse("00000000-0000-0000-0000-000000000000"), Uuid(bytes.Repeat([]byte{0xff}, 16))
+	if i := ids.Search(min); i != 0 || ids.Contains(min) {
+		t.Fatalf("unexpected result %d for %v", i, min)
+	}
+	if i := ids.Search(max); i != len(ids) || ids.Contains(max) {
+		t.Fatalf("unexpected result %d for %v", i, max)
+	}
+	if Uuids(nil).Contains(min) {
+		t.Fatal("empty Uuids should not contain anything")
+	}
+}
+
+func TestUuidsClone(t *testing.T) {
+	ids := Uuids{MakeV4(), nil, MakeV7(), Uuid{}}
+	c := ids.Clone()
+	if len(c) != 4 || !c[0].Equal(ids[0]) || c[1] != nil || !c[2].Equal(ids[2]) {
+		t.Fatalf("want %v got %v", ids, c)
+	}
+	if c[3] == nil || len(c[3]) != 0 {
+		t.Fatalf("Clone should preserve empty elements, got %#v", c[3])
+	}
+	if c := (Uuids{Uuid{}}).Clone(); c[0] == nil {
+		t.Fatal("Clone should preserve empty elements")
+	}
+	c[0][0]++
+	c[2] = append(c[2], 0)
+	if c[0].Equal(ids[0]) || len(ids[2]) != 16 {
+		t.Fatal("Clone should not share memory")
+	}
+	if Uuids(nil).Clone() != nil {
+		t.Fatal("Clone should preserve nil")
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	a, b, c := MakeV4(), MakeV4(), MakeV4()
+	ids := Uuids{b, a, b, c, a, b}
+	got := ids.Dedupe()
+	if len(got) != 3 || !got[0].Equal(b) || !got[1].Equal(a) || !got[2].Equal(c) {
+		t.Fatalf("unexpected result %v", got)
+	}
+	if ids[3] != nil {
+		t.Fatal("removed elements should be cleared")
+	}
+	if got := Uuids(nil).Dedupe(); len(got) != 0 {
+		t.Fatalf("unexpected result %v", got)
+	}
+}
+
+func TestSortUnique(t *testing.T) {
+	a, b, c := MakeV4(), MakeV4(), MakeV4()
+	ids := Uuids{b, a, b, c, a, b}
+	got := ids.SortUnique()
+	if len(got) != 3 || !sort.IsSorted(got) {
+		t.Fatalf("unexpected result %v", got)
+	}
+	for _, id := range []Uuid{a, b, c} {
+		if !got.Contains(id) {
+			t.Fatalf("%v missing from %v", id, got)
+		}
+	}
+	if got := Uuids(nil).SortUnique(); len(got) != 0 {
+		t.Fatalf("unexpected result %v", got)
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	strs := []string{
+		"9b78d54c-8cc9-46bc-ae29-efcba10e1abb",
+		"not a uuid",
+		"{017f22e2-79b0-7cc3-98c4-dc0c0c07398f}",
+	}
+	ids, errs := ParseAll(strs)
+	if len(ids) != 3 || len(errs) != 3 {
+		t.Fatalf("unexpected lengths %d %d", len(ids), len(errs))
+	}
+	if errs[0] != nil || errs[1] != errParseFailed || errs[2] != nil {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if ids[0].String() != strs[0] || ids[1] != nil || ids[2].Version() != 7 {
+		t.Fatalf("unexpected UUIDs %v", ids)
+	}
+	ids, errs = ParseAll([]string{strs[0], strs[2]})
+	if errs != nil || len(ids) != 2 {
+		t.Fatalf("unexpected result %v %v", ids, errs)
+	}
+
+	if _, err := ParseAllFailFast(strs); !errors.Is(err, errParseFailed) || err.Error() != "uuid: element 1: uuid: Parse: invalid value" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	ids, err := ParseAllFailFast([]string{strs[2], strs[0]})
+	if err != nil || !ids[1].Equal(MustParse(strs[0])) {
+		t.Fatalf("unexpected result %v %v", ids, err)
+	}
+}
+
+func BenchmarkParseAll(b *testing.B) {
+	strs := make([]string, 1000)
+	for i := range strs {
+		strs[i] = MakeV4().String()
+	}
+	b.ResetTimer()
+	for n := b.N; n > 0; n-- {
+		ParseAll(strs)
+	}
+}
+
+func TestUuidsJSON(t *testing.T) {
+	ids := Uuids{
+		MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		MustParse("01890a5d-ac96-774b-bcce-b302099a8057"),
+	}
+	b, err := json.Marshal(ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `["f47ac10b-58cc-4372-a567-0e02b2c3d479","01890a5d-ac96-774b-bcce-b302099a8057"]`
+	if string(b) != want {
+		t.Fatalf("want %s got %s", want, b)
+	}
+	var got Uuids
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || !got[0].Equal(ids[0]) || !got[1].Equal(ids[1]) {
+		t.Fatalf("want %v got %v", ids, got)
+	}
+
+	for _, test := range []struct {
+		in   Uuids
+		want string
+	}{
+		{nil, "null"},
+		{Uuids{}, "[]"},
+	} {
+		if b, err := json.Marshal(test.in); err != nil || string(b) != test.want {
+			t.Fatalf("want %s got %s %v", test.want, b, err)
+		}
+	}
+	if _, err := json.Marshal(Uuids{ids[0], nil}); err == nil {
+		t.Fatal("empty element should fail")
+	}
+
+	if err := json.Unmarshal([]byte("null"), &got); err != nil || got != nil {
+		t.Fatalf("unexpected result %v %v", got, err)
+	}
+	for _, in := range []string{
+		`["f47ac10b-58cc-4372-a567-0e02b2c3d479",null]`,
+		`["f47ac10b-58cc-4372-a567-0e02b2c3d479",""]`,
+		`["<empty uuid>"]`,
+		`["9HrBC1jMQ3KlZw4CssPUe"]`,
+		`["f47ac10b-58cc-4372-a567-0e02b2c3d47"]`,
+		`[1]`,
+		`{}`,
+	} {
+		if err := json.Unmarshal([]byte(in), &got); err == nil {
+			t.Fatalf("%s should fail", in)
+		}
+	}
+}
+
+func TestUuidsJSONFormat(t *testing.T) {
+	defer func(format Format) { JSONFormat = format }(JSONFormat)
+	ids := Uuids{
+		MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		MustParse("01890a5d-ac96-774b-bcce-b302099a8057"),
+	}
+	for _, format := range []Format{FormatCanonical, FormatBinary, FormatBase64, FormatURN} {
+		JSONFormat = format
+		b, err := json.Marshal(ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The array must match a []Uuid encoded element by element.
+		want, _ := json.Marshal([]Uuid(ids))
+		if string(b) != string(want) {
+			t.Fatalf("want %s got %s", want, b)
+		}
+		JSONFormat = FormatCanonical
+		var got Uuids
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 || !got[0].Equal(ids[0]) || !got[1].Equal(ids[1]) {
+			t.Fatalf("want %v got %v", ids, got)
+		}
+	}
+	var got Uuids
+	if err := json.Unmarshal([]byte(`["9HrBC1jMQ3KlZw4CssPUeQ"]`), &got); err != nil || !got[0].Equal(ids[0]) {
+		t.Fatalf("unexpected result %v %v", got, err)
+	}
+}
+
+func TestUuidsBinary(t *testing.T) {
+	ids := Uuids{MakeV4(), MakeV7(), MakeV1()}
+	b, err := ids.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 48 || !bytes.Equal(b[16:32], ids[1]) {
+		t.Fatalf("unexpected encoding %x", b)
+	}
+	var got Uuids
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	b[0]++
+	if len(got) != 3 || !got[0].Equal(ids[0]) || !got[2].Equal(ids[2]) {
+		t.Fatalf("want %v got %v", ids, got)
+	}
+	if err := got.UnmarshalBinary(b[:20]); err != errInvalidLength {
+		t.Fatalf("want %v got %v", errInvalidLength, err)
+	}
+	if _, err := (Uuids{ids[0], Uuid{1}}).MarshalBinary(); err == nil {
+		t.Fatal("short element should fail")
+	}
+}
+
+func TestUuidsWriteToLarge(t *testing.T) {
+	ids := make(Uuids, 1000)
+	for i := range ids {
+		ids[i] = FromUint64Pair(0, uint64(i))
+	}
+	var buf bytes.Buffer
+	if n, err := ids.WriteTo(&buf); err != nil || n != 2+16*1000 {
+		t.Fatalf("unexpected result %d %v", n, err)
+	}
+	var got Uuids
+	if _, err := got.ReadFrom(&buf); err != nil || len(got) != len(ids) || !got[999].Equal(ids[999]) {
+		t.Fatalf("unexpected result %d %v", len(got), err)
+	}
+}
+
+func TestUuidsWriteTo(t *testing.T) {
+	lists := []Uuids{{MakeV4(), MakeV4()}, {}, {MakeV7()}}
+	var buf bytes.Buffer
+	for _, ids := range lists {
+		n, err := ids.WriteTo(&buf)
+		if err != nil || n != int64(1+16*len(ids)) {
+			t.Fatalf("unexpected result %d %v", n, err)
+		}
+	}
+	for _, want := range lists {
+		var got Uuids
+		n, err := got.ReadFrom(&buf)
+		if err != nil || n != int64(1+16*len(want)) {
+			t.Fatalf("unexpected result %d %v", n, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("want %v got %v", want, got)
+		}
+		for i := range want {
+			if !got[i].Equal(want[i]) {
+				t.Fatalf("want %v got %v", want, got)
+			}
+		}
+	}
+	var got Uuids
+	if _, err := got.ReadFrom(&buf); err != io.EOF {
+		t.Fatalf("want %v got %v", io.EOF, err)
+	}
+	if _, err := got.ReadFrom(bytes.NewReader([]byte{2, 1, 2, 3})); err != io.ErrUnexpectedEOF {
+		t.Fatalf("want %v got %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	ids := make(Uuids, 6)
+	for i := range ids {
+		ids[i] = FromUint64Pair(0, uint64(i))
+	}
+	a := Uuids{ids[0], ids[1], ids[3], ids[4]}
+	b := Uuids{ids[1], ids[2], ids[4], ids[5]}
+	tests := []struct {
+		name string
+		got  Uuids
+		want Uuids
+	}{
+		{"Union", Union(a, b), ids},
+		{"Intersect", Intersect(a, b), Uuids{ids[1], ids[4]}},
+		{"Difference", Difference(a, b), Uuids{ids[0], ids[3]}},
+		{"Difference", Difference(b, a), Uuids{ids[2], ids[5]}},
+		{"Union", Union(nil, b), b},
+		{"Intersect", Intersect(a, nil), Uuids{}},
+		{"Difference", Difference(a, nil), a},
+	}
+	for _, test := range tests {
+		if len(test.got) != len(test.want) {
+			t.Fatalf("%s: want %v got %v", test.name, test.want, test.got)
+		}
+		for i := range test.want {
+			if !test.got[i].Equal(test.want[i]) {
+				t.Fatalf("%s: want %v got %v", test.name, test.want, test.got)
+			}
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	a := MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	b := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	c := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	ids := Uuids{c, b, a}
+	if !ids.Min().Equal(a) || !ids.Max().Equal(b) {
+		t.Fatalf("unexpected min %v max %v", ids.Min(), ids.Max())
+	}
+	if !MinOf(b, c).Equal(c) || !MaxOf(c, a).Equal(c) {
+		t.Fatal("unexpected MinOf or MaxOf result")
+	}
+	if Uuids(nil).Min() != nil || MaxOf[Uuid]() != nil {
+		t.Fatal("empty lists should give nil")
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// gregorianOffset is the number of 100-nanosecond intervals between the
+// start of the Gregorian calendar (1582-10-15) and the Unix epoch.
+const gregorianOffset = 0x01b21dd213814000
+
+// nextV1 returns the timestamp, clock sequence and node for the next V1
+// UUID. The clock sequence and node are chosen randomly once per generator,
+// unless Node is set; a random node has the multicast bit set, as RFC 4122
+// section 4.5 requires for node IDs that are not IEEE 802 addresses.
+// Timestamps are strictly increasing, unless the clock goes backwards and
+// ClockRollback is BumpClockSequence. Both are chosen again after
+// ReseedAfterFork.
+//
+// The clock is read with the lock held, so that a caller that read it
+// earlier cannot record its reading after a later one and be mistaken for
+// a rollback.
+func (g *StdGenerator) nextV1() (uint64, uint16, []byte, error) {
+	g.v1Lock.Lock()
+	defer g.v1Lock.Unlock()
+	if gen := reseedGeneration.Load(); g.v1Node == nil || g.v1Reseed != gen {
+		if g.Node != nil && len(g.Node) != 6 {
+			return 0, 0, nil, errInvalidNode
+		}
+		b := make([]byte, 8)
+		if err := g.read(b); err != nil {
+			return 0, 0, nil, err
+		}
+		g.v1ClockSeq = binary.BigEndian.Uint16(b) & 0x3fff
+		g.v1Node = b[2:]
+		g.v1Node[0] |= 0x01
+		if g.Node != nil {
+			copy(g.v1Node, g.Node)
+		}
+		g.v1Reseed = gen
+	}
+	// Timestamps advanced past the clock when several UUIDs share a tick
+	// are not rollbacks, so compare with the last reading of the clock
+	// rather than the last timestamp used.
+	now := g.now()
+	ts := uint64(now.UnixNano()/100) + gregorianOffset
+	last := g.v1LastClock
+	g.v1LastClock = ts
+	if ts < last {
+		if g.OnClockRollback != nil {
+			g.OnClockRollback(now, v1ToTime(last))
+		}
+		if g.ClockRollback == BumpClockSequence {
+			g.v1ClockSeq = (g.v1ClockSeq + 1) & 0x3fff
+			g.v1LastTime = ts
+			return ts, g.v1ClockSeq, g.v1Node, nil
+		}
+	}
+	if ts <= g.v1LastTime {
+		ts = g.v1LastTime + 1
+	}
+	g.v1LastTime = ts
+	return ts, g.v1ClockSeq, g.v1Node, nil
+}
+
+// putV1 lays out a V1 UUID from its fields.
+func putV1(uuid Uuid, ts uint64, seq uint16, node []byte) {
+	binary.BigEndian.PutUint32(uuid[0:], uint32(ts))
+	binary.BigEndian.PutUint16(uuid[4:], uint16(ts>>32))
+	binary.BigEndian.PutUint16(uuid[6:], uint16(ts>>48)&0x0fff|0x1000)
+	binary.BigEndian.PutUint16(uuid[8:], seq&0x3fff|0x8000)
+	copy(uuid[10:], node)
+}
+
+// putV6 lays out a V6 UUID, which reorders the V1 timestamp to put its
+// most significant bits first.
+func putV6(uuid Uuid, ts uint64, seq uint16, node []byte) {
+	binary.BigEndian.PutUint32(uuid[0:], uint32(ts>>28))
+	binary.BigEndian.PutUint16(uuid[4:], uint16(ts>>12))
+	binary.BigEndian.PutUint16(uuid[6:], uint16(ts)&0x0fff|0x6000)
+	binary.BigEndian.PutUint16(uuid[8:], seq&0x3fff|0x8000)
+	copy(uuid[10:], node)
+}
+
+// v1Time returns the 60-bit timestamp of a V1 UUID.
+func (uuid Uuid) v1Time() uint64 {
+	return uint64(binary.BigEndian.Uint32(uuid[0:])) |
+		uint64(binary.BigEndian.Uint16(uuid[4:]))<<32 |
+		uint64(binary.BigEndian.Uint16(uuid[6:])&0x0fff)<<48
+}
+
+// v1ToTime converts a 60-bit V1 timestamp to a time.
+func v1ToTime(ts uint64) time.Time {
+	t := int64(ts - gregorianOffset)
+	return time.Unix(t/1e7, t%1e7*100)
+}
+
+// NewV1 makes a Version 1 (time-based) UUID, with a random node ID.
+func (g *StdGenerator) NewV1() (Uuid, error) {
+	ts, seq, node, err := g.nextV1()
+	if err != nil {
+		return nil, err
+	}
+	id := make(Uuid, 16)
+	putV1(id, ts, seq, node)
+	countGenerated(1)
+	return id, nil
+}
+
+// NewV1 makes a Version 1 (time-based) UUID with the default generator.
+// Unlike MakeV1 it returns an error if random data cannot be read.
+func NewV1() (Uuid, error) {
+	return defaultGenerator().NewV1()
+}
+
+// Make Version 1 (time-based) UUID, with a random node ID.
+func MakeV1() Uuid {
+	return mustMake(defaultGenerator().NewV1())
+}
+
+// v6Time returns the 60-bit timestamp of a V6 UUID, which holds the V1
+// timestamp most significant bits first.
+func (uuid Uuid) v6Time() uint64 {
+	return uint64(binary.BigEndian.Uint32(uuid[0:]))<<28 |
+		uint64(binary.BigEndian.Uint16(uuid[4:]))<<12 |
+		uint64(binary.BigEndian.Uint16(uuid[6:])&0x0fff)
+}
+
+// Time returns the time embedded in a Version 1, 6 or 7 UUID. The result
+// is false for other versions.
+func (uuid Uuid) Time() (time.Time, bool) {
+	switch uuid.Version() {
+	case 1:
+		return v1ToTime(uuid.v1Time()), true
+	case 6:
+		return v1ToTime(uuid.v6Time()), true
+	case 7:
+		var b [8]byte
+		copy(b[2:], uuid[:6])
+		return time.UnixMilli(int64(binary.BigEndian.Uint64(b[:]))), true
+	}
+	return time.Time{}, false
+}
+
+// CompareTime orders UUIDs chronologically, so that datasets mixing
+// Version 1, 6 and 7 UUIDs can be sorted by creation time. It returns -1,
+// 0 or +1. UUIDs of those versions are ordered by their embedded time, and
+// by their bytes if the times are equal; they sort before UUIDs of other
+// versions, which are ordered by their bytes.
+func CompareTime(a, b Uuid) int {
+	ta, oka := a.Time()
+	tb, okb := b.Time()
+	switch {
+	case oka && okb:
+		if c := ta.Compare(tb); c != 0 {
+			return c
+		}
+	case oka:
+		return -1
+	case okb:
+		return 1
+	}
+	return a.Compare(b)
+}
+
+// MinV6At returns the smallest Version 6 UUID for the 100-nanosecond tick
+// containing t, and MaxV6At the largest. V6 UUIDs sort by time, so the
+// rows created between t1 and t2 are those with IDs in the inclusive range
+// MinV6At(t1) to MaxV6At(t2). Like MinV7At, the bounds are meant for
+// queries and never stored.
+func MinV6At(t time.Time) Uuid {
+	uuid := Make()
+	putV6(uuid, uint64(t.UnixNano()/100)+gregorianOffset, 0, nil)
+	return uuid
+}
+
+// MaxV6At returns the largest Version 6 UUID for the 100-nanosecond tick
+// containing t. See MinV6At.
+func MaxV6At(t time.Time) Uuid {
+	uuid := Make()
+	putV6(uuid, uint64(t.UnixNano()/100)+gregorianOffset, 0x3fff, maxNode)
+	return uuid
+}
+
+// MinV1At returns the Version 1 UUID with the smallest clock sequence and
+// node for the 100-nanosecond tick containing t, and MaxV1At the one with
+// the largest. V1 UUIDs do not sort by their bytes, so the bounds suit
+// stores that order them by time first, as CompareTime does; for
+// Cassandra's timeuuid ordering use MinTimeuuid and MaxTimeuuid.
+func MinV1At(t time.Time) Uuid {
+	uuid := Make()
+	putV1(uuid, uint64(t.UnixNano()/100)+gregorianOffset, 0, nil)
+	return uuid
+}
+
+// MaxV1At returns the Version 1 UUID with the largest clock sequence and
+// node for the 100-nanosecond tick containing t. See MinV1At.
+func MaxV1At(t time.Time) Uuid {
+	uuid := Make()
+	putV1(uuid, uint64(t.UnixNano()/100)+gregorianOffset, 0x3fff, maxNode)
+	return uuid
+}
+
+var maxNode = []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
@@ -0,0 +1,140 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestV1(t *testing.T) {
+	before := time.Now()
+	uuid := MakeV1()
+	after := time.Now()
+	if uuid.Version() != 1 {
+		t.Fatalf("Invalid V1 UUID: version != 1")
+	}
+	if uuid.Variant() != VariantRFC4122 {
+		t.Fatalf("Invalid V1 UUID: variant %d", uuid.Variant())
+	}
+	if uuid[10]&0x01 == 0 {
+		t.Fatal("Invalid V1 UUID: random node without multicast bit")
+	}
+	ts, ok := uuid.Time()
+	if !ok {
+		t.Fatal("Time of a V1 UUID should be ok")
+	}
+	if ts.Before(before.Truncate(100)) || ts.After(after.Add(time.Millisecond)) {
+		t.Fatalf("Invalid V1 UUID: time %v not in [%v, %v]", ts, before, after)
+	}
+	if _, err := Parse(uuid.String()); err != nil {
+		t.Fatalf("Parsing of %v failed", uuid)
+	}
+}
+
+func TestV1Known(t *testing.T) {
+	// Python: uuid.UUID("3722b9a0-9889-11e2-871e-844bf591482a").time
+	uuid := MustParse("3722b9a0-9889-11e2-871e-844bf591482a")
+	ts, ok := uuid.Time()
+	if !ok {
+		t.Fatal("Time of a V1 UUID should be ok")
+	}
+	expected := time.Date(2013, 3, 29, 15, 56, 27, 750032000, time.UTC)
+	if !ts.Equal(expected) {
+		t.Fatalf("want %v got %v", expected, ts.UTC())
+	}
+}
+
+func TestV1Monotonic(t *testing.T) {
+	prev := MakeV1()
+	for i := 0; i < 10000; i++ {
+		uuid := MakeV1()
+		if prev.v1Time() >= uuid.v1Time() {
+			t.Fatalf("V1 UUIDs out of order: %v then %v", prev, uuid)
+		}
+		prev = uuid
+	}
+}
+
+func TestTimeV7(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	ts, ok := MakeV7().Time()
+	if !ok {
+		t.Fatal("Time of a V7 UUID should be ok")
+	}
+	if ts.Before(before) || ts.After(time.Now().Add(time.Millisecond)) {
+		t.Fatalf("unexpected V7 time %v", ts)
+	}
+	if _, ok := MakeV4().Time(); ok {
+		t.Fatal("Time of a V4 UUID should not be ok")
+	}
+}
+
+func TestCompareTime(t *testing.T) {
+	// The RFC 9562 test vectors for 2022-02-22 19:22:22 UTC.
+	v1 := MustParse("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v6 := MustParse("1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+	v7 := MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	for _, id := range []Uuid{v1, v6, v7} {
+		if ts, ok := id.Time(); !ok || !ts.Equal(want) {
+			t.Fatalf("%v: want %v got %v", id, want, ts)
+		}
+	}
+	later := MustParse("017f22e2-79b1-7cc3-98c4-dc0c0c07398f")
+	v4 := MustParse("00000000-0000-4000-8000-000000000000")
+	tests := []struct {
+		a, b Uuid
+		want int
+	}{
+		{v1, v6, 1},
+		{v6, v1, -1},
+		{v1, v1, 0},
+		{v1, later, -1},
+		{later, v6, 1},
+		{v7, v4, -1},
+		{v4, v1, 1},
+		{v4, v4, 0},
+	}
+	for _, test := range tests {
+		if c := CompareTime(test.a, test.b); c != test.want {
+			t.Fatalf("CompareTime(%v, %v): want %d got %d", test.a, test.b, test.want, c)
+		}
+	}
+}
+
+func TestV1V6Bounds(t *testing.T) {
+	at := time.Date(2022, 2, 22, 19, 22, 22, 123456789, time.UTC)
+	tests := []struct {
+		id       Uuid
+		expected string
+	}{
+		{MinV1At(at), "c2458187-9414-11ec-8000-000000000000"},
+		{MaxV1At(at), "c2458187-9414-11ec-bfff-ffffffffffff"},
+		{MinV6At(at), "1ec9414c-2458-6187-8000-000000000000"},
+		{MaxV6At(at), "1ec9414c-2458-6187-bfff-ffffffffffff"},
+	}
+	tick := at.Truncate(100 * time.Nanosecond)
+	for _, test := range tests {
+		if s := test.id.String(); s != test.expected {
+			t.Fatalf("want %s got %s", test.expected, s)
+		}
+		if tm, _ := test.id.Time(); !tm.Equal(tick) {
+			t.Fatalf("want %v got %v", tick, tm)
+		}
+	}
+	id := MustParse("1ec9414c-2458-6187-b3c8-9f6bdeced846")
+	if id.Less(MinV6At(at)) || MaxV6At(at).Less(id) {
+		t.Fatalf("%v should sort between the V6 bounds", id)
+	}
+	if !MaxV6At(at).Less(MinV6At(at.Add(100 * time.Nanosecond))) {
+		t.Fatal("bounds of consecutive ticks should not overlap")
+	}
+	g := &StdGenerator{Clock: func() time.Time { return at }}
+	v1, _ := g.NewV1()
+	if CompareTime(MinV1At(at), v1) > 0 || CompareTime(v1, MaxV1At(at)) > 0 {
+		t.Fatalf("%v should sort between the V1 bounds", v1)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// nextV7 returns the timestamp and 12-bit counter for the next V7 UUID. The
+// counter makes UUIDs generated within the same millisecond sort in the
+// order they were made (RFC 9562 section 6.2, method 1). When it overflows,
+// or the clock goes backwards, the timestamp is advanced past the last one
+// issued; V7 UUIDs have no clock sequence, so ClockRollback does not apply.
+//
+// The state is a single word holding the timestamp shifted left by 12 bits
+// plus the counter, updated with compare-and-swap instead of a lock.
+// Incrementing the word increments the counter and carries an overflow into
+// the timestamp.
+//
+// Rollbacks are detected against v7LastClock, the latest clock reading, kept
+// apart from the state so that an advanced timestamp is not mistaken for a
+// rollback. It only grows, except when a rollback is reported, and it is
+// loaded before the clock is read, so a later reading stored by a
+// concurrent caller in between is not reported as a rollback.
+func (g *StdGenerator) nextV7() (int64, uint16) {
+	last := g.v7LastClock.Load()
+	now := g.now()
+	ms := uint64(now.UnixMilli())
+	if ms < last {
+		if g.OnClockRollback != nil {
+			g.OnClockRollback(now, time.UnixMilli(int64(last)))
+		}
+		// Follow the clock back, so that the rollback is reported once.
+		g.v7LastClock.CompareAndSwap(last, ms)
+	}
+	for cur := last; ms > cur && !g.v7LastClock.CompareAndSwap(cur, ms); {
+		cur = g.v7LastClock.Load()
+	}
+	for {
+		old := g.v7State.Load()
+		next := ms << 12
+		if next <= old {
+			next = old + 1
+		}
+		if g.v7State.CompareAndSwap(old, next) {
+			return int64(next >> 12), uint16(next & 0xfff)
+		}
+	}
+}
+
+// NewV7 makes a Version 7 (Unix Epoch time-based) UUID.
+func (g *StdGenerator) NewV7() (Uuid, error) {
+	// V7 UUID is of the form: tttttttt-tttt-7sss-yxxx-xxxxxxxxxxxx
+	// where t is the big-endian Unix time in milliseconds, s is a counter
+	// and x is random.
+	id := make(Uuid, 16)
+	if err := g.read(id[8:]); err != nil {
+		return nil, err
+	}
+
+	ms, seq := g.nextV7()
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(ms))
+	copy(id[0:6], ts[2:])
+	id[6] = 0x70 | byte(seq>>8)
+	id[7] = byte(seq)
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	countGenerated(7)
+	return id, nil
+}
+
+// NewV7 makes a Version 7 (Unix Epoch time-based) UUID with the default
+// generator. Unlike MakeV7 it returns an error if random data cannot be read.
+func NewV7() (Uuid, error) {
+	return defaultGenerator().NewV7()
+}
+
+// Make Version 7 (Unix Epoch time-based) UUID.
+func MakeV7() Uuid {
+	return mustMake(defaultGenerator().NewV7())
+}
+
+// MinV7At returns the smallest Version 7 UUID that can be made in the
+// millisecond containing t, and MaxV7At the largest. Since V7 UUIDs sort by
+// time, the rows created between t1 and t2 are those with IDs in the
+// inclusive range MinV7At(t1) to MaxV7At(t2), a primary key range scan.
+// The bounds are meant for queries; they are not random and should not be
+// stored.
+func MinV7At(t time.Time) Uuid {
+	return v7Bound(t, 0x00)
+}
+
+// MaxV7At returns the largest Version 7 UUID for the millisecond containing
+// t. See MinV7At.
+func MaxV7At(t time.Time) Uuid {
+	return v7Bound(t, 0xff)
+}
+
+func v7Bound(t time.Time, fill byte) Uuid {
+	uuid := Make()
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(t.UnixMilli()))
+	copy(uuid[0:6], ts[2:])
+	for i := 6; i < 16; i++ {
+		uuid[i] = fill
+	}
+	uuid[6] = 0x70 | fill&0x0f
+	uuid[8] = 0x80 | fill&0x3f
+	return uuid
+}
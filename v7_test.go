@@ -0,0 +1,137 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestV7(t *testing.T) {
+	before := time.Now().UnixMilli()
+	uuid := MakeV7()
+	after := time.Now().UnixMilli()
+	if uuid.Version() != 7 {
+		t.Fatalf("Invalid V7 UUID: version != 7")
+	}
+	if uuid.Variant() != VariantRFC4122 {
+		t.Fatalf("Invalid V7 UUID: variant %d", uuid.Variant())
+	}
+	ms := int64(uuid[0])<<40 | int64(uuid[1])<<32 | int64(uuid[2])<<24 |
+		int64(uuid[3])<<16 | int64(uuid[4])<<8 | int64(uuid[5])
+	if ms < before || ms > after+1 {
+		t.Fatalf("Invalid V7 UUID: timestamp %d not in [%d, %d]", ms, before, after)
+	}
+	if _, err := Parse(uuid.String()); err != nil {
+		t.Fatalf("Parsing of %v failed", uuid)
+	}
+}
+
+func TestV7Monotonic(t *testing.T) {
+	prev := MakeV7()
+	for i := 0; i < 10000; i++ {
+		uuid := MakeV7()
+		if !prev.Less(uuid) {
+			t.Fatalf("V7 UUIDs out of order: %v then %v", prev, uuid)
+		}
+		prev = uuid
+	}
+}
+
+func TestV7Counter(t *testing.T) {
+	now := time.UnixMilli(1645557742000)
+	g := &StdGenerator{Clock: func() time.Time { return now }}
+	ms, seq := g.nextV7()
+	if ms != 1645557742000 || seq != 0 {
+		t.Fatalf("unexpected first state %d %d", ms, seq)
+	}
+	for i := 1; i <= 0xfff; i++ {
+		if ms, seq = g.nextV7(); ms != 1645557742000 || seq != uint16(i) {
+			t.Fatalf("unexpected state %d %d", ms, seq)
+		}
+	}
+	// Counter overflow advances the timestamp.
+	if ms, seq = g.nextV7(); ms != 1645557742001 || seq != 0 {
+		t.Fatalf("unexpected state after overflow %d %d", ms, seq)
+	}
+	// A clock going backwards does not move the timestamp back.
+	now = now.Add(-time.Second)
+	if ms, seq = g.nextV7(); ms != 1645557742001 || seq != 1 {
+		t.Fatalf("unexpected state after rollback %d %d", ms, seq)
+	}
+	now = now.Add(2 * time.Second)
+	if ms, seq = g.nextV7(); ms != 1645557743000 || seq != 0 {
+		t.Fatalf("unexpected state after advance %d %d", ms, seq)
+	}
+}
+
+func TestV7Concurrent(t *testing.T) {
+	var g StdGenerator
+	const goroutines, count = 8, 2000
+	ids := make(chan Uuid, goroutines*count)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				id, _ := g.NewV7()
+				ids <- id
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	seen := make(map[string]bool)
+	for id := range ids {
+		// The timestamp and counter alone must be unique.
+		if prefix := string(id[:8]); seen[prefix] {
+			t.Fatalf("duplicate timestamp and counter in %v", id)
+		} else {
+			seen[prefix] = true
+		}
+	}
+}
+
+func BenchmarkMakeV7Parallel(b *testing.B) {
+	b.SetBytes(16)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = MakeV7()
+		}
+	})
+}
+
+func BenchmarkMakeV7(b *testing.B) {
+	b.SetBytes(16)
+	for n := b.N; n > 0; n-- {
+		_ = MakeV7()
+	}
+}
+
+func TestV7Bounds(t *testing.T) {
+	at := time.Date(2022, 2, 22, 19, 22, 22, 500000, time.UTC)
+	min, max := MinV7At(at), MaxV7At(at)
+	if s := min.String(); s != "017f22e2-79b0-7000-8000-000000000000" {
+		t.Fatalf("unexpected min %s", s)
+	}
+	if s := max.String(); s != "017f22e2-79b0-7fff-bfff-ffffffffffff" {
+		t.Fatalf("unexpected max %s", s)
+	}
+	if min.Version() != 7 || max.Variant() != VariantRFC4122 {
+		t.Fatalf("unexpected version or variant %v %v", min, max)
+	}
+	g := &StdGenerator{Clock: func() time.Time { return at }}
+	for i := 0; i < 100; i++ {
+		id, _ := g.NewV7()
+		if id.Less(min) || max.Less(id) {
+			t.Fatalf("%v outside [%v, %v]", id, min, max)
+		}
+	}
+	if !max.Less(MinV7At(at.Add(time.Millisecond))) {
+		t.Fatal("bounds of consecutive milliseconds should not overlap")
+	}
+}
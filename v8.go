@@ -0,0 +1,107 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// embedV8 builds a Version 8 UUID carrying up to 12 bytes of payload p. The
+// payload bits are stored in order around the version and variant bits:
+//
+//	bytes 0-5    p[0:6]
+//	byte 6       version 8 | high nibble of p[6]
+//	byte 7       low nibble of p[6] | high nibble of p[7]
+//	byte 8       variant 0b10, two zero bits | low nibble of p[7]
+//	bytes 9-12   p[8:12]
+//	bytes 13-15  zero
+//
+// Payloads therefore sort in the same order as the UUIDs embedding them.
+// Bytes of p beyond its length are treated as zero.
+func embedV8(p []byte) Uuid {
+	var b [12]byte
+	copy(b[:], p)
+	uuid := Make()
+	copy(uuid[0:6], b[0:6])
+	uuid[6] = 0x80 | b[6]>>4
+	uuid[7] = b[6]<<4 | b[7]>>4
+	uuid[8] = 0x80 | b[7]&0x0f
+	copy(uuid[9:13], b[8:12])
+	return uuid
+}
+
+// extractV8 reverses embedV8 for a payload of len(p) bytes. It reports
+// false if uuid was not produced by embedV8 from a payload of that length.
+func (uuid Uuid) extractV8(p []byte) bool {
+	if len(uuid) != 16 || uuid[6]>>4 != 8 || uuid[8]&0xf0 != 0x80 {
+		return false
+	}
+	var b [12]byte
+	copy(b[0:6], uuid[0:6])
+	b[6] = uuid[6]<<4 | uuid[7]>>4
+	b[7] = uuid[7]<<4 | uuid[8]&0x0f
+	copy(b[8:12], uuid[9:13])
+	for _, c := range append(b[len(p):], uuid[13:]...) {
+		if c != 0 {
+			return false
+		}
+	}
+	copy(p, b[:])
+	return true
+}
+
+// FromSnowflake wraps a 64-bit Snowflake ID in a Version 8 UUID. UUIDs made
+// from non-negative IDs sort in the same order as the IDs, so time ordering
+// is preserved.
+func FromSnowflake(id int64) Uuid {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(id))
+	return embedV8(b[:])
+}
+
+// Snowflake extracts the Snowflake ID from a UUID made by FromSnowflake. It
+// reports false for any other UUID.
+func (uuid Uuid) Snowflake() (int64, bool) {
+	var b [8]byte
+	if !uuid.extractV8(b[:]) {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(b[:])), true
+}
+
+// FromXID wraps a 12-byte xid (github.com/rs/xid) in a Version 8 UUID using
+// the layout documented on embedV8, leaving the last three bytes zero. An
+// xid.ID can be passed directly. UUIDs sort in the same order as the xids
+// they wrap.
+func FromXID(id [12]byte) Uuid {
+	return embedV8(id[:])
+}
+
+// XID extracts the xid from a UUID made by FromXID. It reports false for
+// UUIDs not in that layout. The result is assignable to xid.ID.
+func (uuid Uuid) XID() ([12]byte, bool) {
+	var id [12]byte
+	if !uuid.extractV8(id[:]) {
+		return id, false
+	}
+	return id, true
+}
+
+// NewKeyed derives a Version 8 UUID from name with HMAC-SHA256 under key,
+// keeping the first 122 bits of the MAC. Like Version 5 UUIDs, the same
+// key and name always give the same UUID, but without the key the name
+// cannot be recovered by hashing guesses, so it is suitable for IDs
+// derived from personal data such as email addresses. Keep the key secret
+// and stable; changing it changes every derived ID.
+func NewKeyed(key, name []byte) Uuid {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(name)
+	uuid := Uuid(mac.Sum(nil)[:16:16])
+	uuid[6] = (uuid[6] & 0x0f) | 0x80
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return uuid
+}
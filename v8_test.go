@@ -0,0 +1,103 @@
+// Copyright 2013 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSnowflake(t *testing.T) {
+	// A Twitter Snowflake ID.
+	const id = 1541815603606036480
+	uuid := FromSnowflake(id)
+	if uuid.Version() != 8 || uuid.Variant() != VariantRFC4122 {
+		t.Fatalf("Invalid V8 UUID %v", uuid)
+	}
+	if _, err := Parse(uuid.String()); err != nil {
+		t.Fatalf("Parsing of %v failed", uuid)
+	}
+	if s := uuid.String(); s != "1565a11f-6217-8a00-8000-000000000000" {
+		t.Fatalf("unexpected UUID %s", s)
+	}
+	for _, id := range []int64{0, 1, id, math.MaxInt64, -1} {
+		id2, ok := FromSnowflake(id).Snowflake()
+		if !ok || id2 != id {
+			t.Fatalf("want %d got %d, %v", id, id2, ok)
+		}
+	}
+	if _, ok := MakeV4().Snowflake(); ok {
+		t.Fatal("Snowflake of a V4 UUID should not be ok")
+	}
+	uuid[15] = 1
+	if _, ok := uuid.Snowflake(); ok {
+		t.Fatal("Snowflake of a UUID with trailing bits should not be ok")
+	}
+}
+
+func TestSnowflakeOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 1000; i++ {
+		a, b := r.Int63(), r.Int63()
+		if (a < b) != FromSnowflake(a).Less(FromSnowflake(b)) {
+			t.Fatalf("order of %d and %d is not preserved", a, b)
+		}
+	}
+}
+
+// xid has the same definition as xid.ID in github.com/rs/xid.
+type xid [12]byte
+
+func TestXID(t *testing.T) {
+	id := xid{0x4d, 0x88, 0xe1, 0x5b, 0x60, 0xf4, 0x86, 0xe4, 0x28, 0x41, 0x2d, 0xc9}
+	uuid := FromXID(id)
+	if uuid.Version() != 8 || uuid.Variant() != VariantRFC4122 {
+		t.Fatalf("Invalid V8 UUID %v", uuid)
+	}
+	if s := uuid.String(); s != "4d88e15b-60f4-886e-8428-412dc9000000" {
+		t.Fatalf("unexpected UUID %s", s)
+	}
+	var id2 xid
+	id2, ok := uuid.XID()
+	if !ok || id2 != id {
+		t.Fatalf("want %x got %x, %v", id, id2, ok)
+	}
+	if _, ok := MakeV4().XID(); ok {
+		t.Fatal("XID of a V4 UUID should not be ok")
+	}
+	uuid[13] = 1
+	if _, ok := uuid.XID(); ok {
+		t.Fatal("XID of a UUID with trailing bits should not be ok")
+	}
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 1000; i++ {
+		var a, b xid
+		r.Read(a[:])
+		r.Read(b[:])
+		if (string(a[:]) < string(b[:])) != FromXID(a).Less(FromXID(b)) {
+			t.Fatalf("order of %x and %x is not preserved", a, b)
+		}
+	}
+}
+
+func TestNewKeyed(t *testing.T) {
+	key := []byte("secret")
+	id := NewKeyed(key, []byte("alice@example.com"))
+	const want = "a398d49c-e198-8b36-82bc-4dbd110121e3"
+	if id.String() != want {
+		t.Fatalf("want %s got %v", want, id)
+	}
+	if id.Version() != 8 || id.Variant() != VariantRFC4122 {
+		t.Fatalf("unexpected version or variant of %v", id)
+	}
+	if !NewKeyed(key, []byte("alice@example.com")).Equal(id) {
+		t.Fatal("NewKeyed should be deterministic")
+	}
+	if NewKeyed(key, []byte("bob@example.com")).Equal(id) || NewKeyed([]byte("other"), []byte("alice@example.com")).Equal(id) {
+		t.Fatal("different inputs should give different UUIDs")
+	}
+}